@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+	"github.com/bishnuag/graphql-inspector/pkg/loader"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// checkResponseCmd represents the check-response command
+var checkResponseCmd = &cobra.Command{
+	Use:   "check-response <document> <schema> <response.json>",
+	Short: "Check a recorded JSON response against an operation's selections",
+	Long: `Check that a recorded JSON response has the shape its operation and schema promise.
+
+The check-response command walks the operation's selection set alongside the response data.
+It verifies that every selected field or alias is present, that nulls only appear where the
+schema allows them, that lists, scalars and enum values have the right shape, and that
+__typename values are possible types of their interfaces and unions.
+
+Examples:
+  # Check a recorded response for the only operation in a document
+  graphql-inspector check-response getUser.graphql schema.graphql getUser.response.json
+
+  # Pick the operation when the document defines several
+  graphql-inspector check-response queries.graphql schema.graphql user.json --operation GetUser
+
+  # Output in JSON format
+  graphql-inspector check-response getUser.graphql schema.graphql getUser.response.json --json`,
+	Args: cobra.ExactArgs(3),
+	RunE: runCheckResponse,
+}
+
+func init() {
+	rootCmd.AddCommand(checkResponseCmd)
+
+	// Check-response-specific flags
+	checkResponseCmd.Flags().String("operation", "", "name of the operation that produced the response")
+	checkResponseCmd.Flags().Bool("fail-on-error", true, "exit with non-zero code if the response doesn't match")
+
+	// Bind flags to viper
+	viper.BindPFlag("check-response.operation", checkResponseCmd.Flags().Lookup("operation"))
+	viper.BindPFlag("check-response.fail-on-error", checkResponseCmd.Flags().Lookup("fail-on-error"))
+}
+
+func runCheckResponse(cmd *cobra.Command, args []string) error {
+	if viper.GetBool("verbose") {
+		fmt.Fprintf(os.Stderr, "Checking response %s for %s against schema: %s\n", args[2], args[0], args[1])
+	}
+
+	// Load the document, schema and response
+	document, err := loader.LoadDocument(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load document: %w", err)
+	}
+
+	schema, err := loader.LoadSchema(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to load schema: %w", err)
+	}
+
+	response, err := loader.LoadResponse(args[2])
+	if err != nil {
+		return fmt.Errorf("failed to load response: %w", err)
+	}
+
+	// Check the response
+	result, err := core.CheckResponse(schema, *document, viper.GetString("check-response.operation"), response)
+	if err != nil {
+		return fmt.Errorf("failed to check response: %w", err)
+	}
+
+	// Output results
+	if viper.GetBool("json") {
+		if err := outputCheckResponseJSON(result); err != nil {
+			return err
+		}
+	} else {
+		outputCheckResponseText(result)
+	}
+
+	if !result.IsValid && viper.GetBool("check-response.fail-on-error") {
+		return fmt.Errorf("response does not match the operation")
+	}
+	return nil
+}
+
+func outputCheckResponseJSON(result *core.ResponseCheckResult) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func outputCheckResponseText(result *core.ResponseCheckResult) {
+	fmt.Printf("Response Check: %s (%s)\n", result.Operation, result.Source)
+	fmt.Println("===============")
+
+	if len(result.Diagnostics) == 0 {
+		fmt.Println("✅ Response matches the operation!")
+		return
+	}
+
+	for _, diagnostic := range result.Diagnostics {
+		printDiagnostic(diagnostic)
+	}
+	fmt.Println()
+
+	if result.IsValid {
+		fmt.Println("✅ Response matches the operation, with warnings")
+	} else {
+		fmt.Println("❌ Response does not match the operation")
+	}
+}
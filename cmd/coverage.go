@@ -4,6 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/bishnuag/graphql-inspector/pkg/core"
 	"github.com/bishnuag/graphql-inspector/pkg/loader"
@@ -19,7 +25,8 @@ var coverageCmd = &cobra.Command{
 	
 The coverage command analyzes how much of your GraphQL schema is actually used
 by your GraphQL documents (queries, mutations, subscriptions). It provides
-detailed information about type and field coverage.
+detailed information about type and field coverage, and about the field arguments,
+enum values and input object fields the documents pass.
 
 Examples:
   # Analyze coverage
@@ -27,9 +34,28 @@ Examples:
   
   # Set coverage threshold
   graphql-inspector coverage queries/ schema.graphql --threshold 0.8
+
+  # Hold some types to their own thresholds
+  graphql-inspector coverage queries/ schema.graphql --fail-on-threshold --type-threshold "Viewer*=0.95" --type-threshold "@internal=0.2"
   
   # Find unused types and fields
-  graphql-inspector coverage queries/ schema.graphql --show-unused`,
+  graphql-inspector coverage queries/ schema.graphql --show-unused
+  
+  # Only count types and output fields
+  graphql-inspector coverage queries/ schema.graphql --arguments=false --enum-values=false --input-fields=false
+
+  # Save a baseline on main, then fail pull requests whose coverage drops
+  graphql-inspector coverage queries/ schema.graphql --save-baseline coverage.json
+  graphql-inspector coverage queries/ schema.graphql --baseline coverage.json --tolerance 0.01
+
+  # Browse coverage as a static HTML site
+  graphql-inspector coverage queries/ schema.graphql --format html --out coverage-report/
+
+  # Print the schema with hit counts as trailing comments
+  graphql-inspector coverage queries/ schema.graphql --format sdl > schema.coverage.graphql
+
+  # Weight fields by gateway traffic and list those without requests in 30 days
+  graphql-inspector coverage queries/ schema.graphql --usage-file traffic.jsonl --traffic-days 30`,
 	Args: cobra.ExactArgs(2),
 	RunE: runCoverage,
 }
@@ -42,12 +68,34 @@ func init() {
 	coverageCmd.Flags().Bool("show-unused", false, "show unused types and fields")
 	coverageCmd.Flags().Bool("show-details", false, "show detailed coverage information")
 	coverageCmd.Flags().Bool("fail-on-threshold", false, "exit with non-zero code if coverage is below threshold")
+	coverageCmd.Flags().StringArray("type-threshold", []string{}, "coverage threshold for the types matching a glob or directive (e.g. Viewer*=0.95 or @internal=0.2)")
+	coverageCmd.Flags().Bool("arguments", true, "include field argument coverage")
+	coverageCmd.Flags().Bool("enum-values", true, "include enum value coverage")
+	coverageCmd.Flags().Bool("input-fields", true, "include input object field coverage")
+	coverageCmd.Flags().String("usage-file", "", "JSON lines of operation traffic ({operationName|hash, count, timestamp}) to weight usage counts and field hits by; hash is the SHA-256 of the printed operation followed by the fragments it spreads")
+	coverageCmd.Flags().Int("traffic-days", 0, "only count traffic from the last N days (0 counts all)")
+	coverageCmd.Flags().String("baseline", "", "coverage JSON of an earlier run to compare against; fails when coverage decreases")
+	coverageCmd.Flags().String("save-baseline", "", "write the coverage result to a JSON file for later comparison")
+	coverageCmd.Flags().Float64("tolerance", 0, "allowed decrease in overall or per-type coverage from the baseline")
+	coverageCmd.Flags().String("format", "text", "output format: text, json, html or sdl")
+	coverageCmd.Flags().String("out", "coverage-report", "directory to write the html report to")
 	
 	// Bind flags to viper
 	viper.BindPFlag("coverage.threshold", coverageCmd.Flags().Lookup("threshold"))
 	viper.BindPFlag("coverage.show-unused", coverageCmd.Flags().Lookup("show-unused"))
 	viper.BindPFlag("coverage.show-details", coverageCmd.Flags().Lookup("show-details"))
 	viper.BindPFlag("coverage.fail-on-threshold", coverageCmd.Flags().Lookup("fail-on-threshold"))
+	viper.BindPFlag("coverage.type-threshold", coverageCmd.Flags().Lookup("type-threshold"))
+	viper.BindPFlag("coverage.arguments", coverageCmd.Flags().Lookup("arguments"))
+	viper.BindPFlag("coverage.enum-values", coverageCmd.Flags().Lookup("enum-values"))
+	viper.BindPFlag("coverage.input-fields", coverageCmd.Flags().Lookup("input-fields"))
+	viper.BindPFlag("coverage.usage-file", coverageCmd.Flags().Lookup("usage-file"))
+	viper.BindPFlag("coverage.traffic-days", coverageCmd.Flags().Lookup("traffic-days"))
+	viper.BindPFlag("coverage.baseline", coverageCmd.Flags().Lookup("baseline"))
+	viper.BindPFlag("coverage.save-baseline", coverageCmd.Flags().Lookup("save-baseline"))
+	viper.BindPFlag("coverage.tolerance", coverageCmd.Flags().Lookup("tolerance"))
+	viper.BindPFlag("coverage.format", coverageCmd.Flags().Lookup("format"))
+	viper.BindPFlag("coverage.out", coverageCmd.Flags().Lookup("out"))
 }
 
 func runCoverage(cmd *cobra.Command, args []string) error {
@@ -84,6 +132,23 @@ func runCoverage(cmd *cobra.Command, args []string) error {
 		Schema:    schema,
 		Documents: documents,
 		Threshold: viper.GetFloat64("coverage.threshold"),
+		IncludeArguments:   viper.GetBool("coverage.arguments"),
+		IncludeEnumValues:  viper.GetBool("coverage.enum-values"),
+		IncludeInputFields: viper.GetBool("coverage.input-fields"),
+	}
+	
+	// Load operation traffic to weight coverage by
+	if usageFile := viper.GetString("coverage.usage-file"); usageFile != "" {
+		options.Traffic, err = loader.LoadTraffic(usageFile)
+		if err != nil {
+			return fmt.Errorf("failed to load usage file: %w", err)
+		}
+		if options.Traffic == nil {
+			options.Traffic = []core.OperationTraffic{}
+		}
+		if days := viper.GetInt("coverage.traffic-days"); days > 0 {
+			options.TrafficSince = time.Now().AddDate(0, 0, -days)
+		}
 	}
 	
 	// Analyze coverage
@@ -108,28 +173,167 @@ func runCoverage(cmd *cobra.Command, args []string) error {
 		}
 	}
 	
+	// Evaluate per-type thresholds
+	thresholds, err := coverageThresholdsFromConfig()
+	if err != nil {
+		return err
+	}
+	thresholdResults := core.EvaluateCoverageThresholds(schema, result, thresholds)
+	
+	// Compare against the baseline of an earlier run
+	var comparison *core.CoverageComparison
+	if baselinePath := viper.GetString("coverage.baseline"); baselinePath != "" {
+		baseline, err := loader.LoadCoverageBaseline(baselinePath)
+		if err != nil {
+			return fmt.Errorf("failed to load coverage baseline: %w", err)
+		}
+		comparison = core.CompareCoverage(baseline, result, viper.GetFloat64("coverage.tolerance"))
+	}
+	
+	// Save the result after comparing, so a run can ratchet its own baseline
+	if savePath := viper.GetString("coverage.save-baseline"); savePath != "" {
+		if err := saveCoverageBaseline(savePath, result); err != nil {
+			return err
+		}
+	}
+	
 	// Output results
+	format := viper.GetString("coverage.format")
 	if viper.GetBool("json") {
-		return outputCoverageJSON(result, unusedTypes, unusedFields)
-	} else {
-		return outputCoverageText(result, unusedTypes, unusedFields)
+		format = "json"
+	}
+	
+	switch format {
+	case "json":
+		err = outputCoverageJSON(result, unusedTypes, unusedFields, thresholdResults, comparison)
+	case "html":
+		err = outputCoverageHTML(schema, documents, result, viper.GetString("coverage.out"))
+	case "sdl", "graphql":
+		err = outputCoverageSDL(schema, result)
+	case "text", "":
+		err = outputCoverageText(result, unusedTypes, unusedFields, thresholdResults, comparison)
+	default:
+		err = fmt.Errorf("unknown output format %q", format)
+	}
+	if err != nil {
+		return err
 	}
+	
+	// Check failure conditions
+	if viper.GetBool("coverage.fail-on-threshold") {
+		if err := checkCoverageThresholds(result, thresholdResults); err != nil {
+			return err
+		}
+	}
+	if comparison != nil && comparison.Regressed {
+		return fmt.Errorf("coverage decreased from baseline by more than %.2f%%", comparison.Tolerance*100)
+	}
+	return nil
 }
 
-func outputCoverageJSON(result *core.CoverageResult, unusedTypes []string, unusedFields map[string][]string) error {
+// coverageThresholdsFromConfig reads per-type thresholds from the thresholds.types
+// config list and --type-threshold pattern=ratio flags
+func coverageThresholdsFromConfig() ([]core.CoverageThreshold, error) {
+	var thresholds []core.CoverageThreshold
+	if err := viper.UnmarshalKey("thresholds.types", &thresholds); err != nil {
+		return nil, fmt.Errorf("invalid thresholds.types config: %w", err)
+	}
+	
+	for _, value := range viper.GetStringSlice("coverage.type-threshold") {
+		i := strings.LastIndex(value, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid type threshold %q, expected pattern=ratio", value)
+		}
+		ratio, err := strconv.ParseFloat(value[i+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type threshold %q: %w", value, err)
+		}
+		thresholds = append(thresholds, core.CoverageThreshold{Pattern: value[:i], Coverage: ratio})
+	}
+	return thresholds, nil
+}
+
+// checkCoverageThresholds returns an error listing the overall threshold and
+// every per-type threshold that failed
+func checkCoverageThresholds(result *core.CoverageResult, thresholdResults []core.ThresholdResult) error {
+	var failures []string
+	threshold := viper.GetFloat64("coverage.threshold")
+	if result.Coverage < threshold {
+		failures = append(failures, fmt.Sprintf("coverage %.2f%% is below threshold %.2f%%", result.Coverage*100, threshold*100))
+	}
+	for _, evaluated := range thresholdResults {
+		if !evaluated.Passed {
+			failures = append(failures, fmt.Sprintf("%s coverage %.2f%% is below threshold %.2f%%", evaluated.Pattern, evaluated.Coverage*100, evaluated.Threshold*100))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// saveCoverageBaseline writes a coverage result for a later --baseline comparison
+func saveCoverageBaseline(path string, result *core.CoverageResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode coverage baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write coverage baseline: %w", err)
+	}
+	return nil
+}
+
+func outputCoverageJSON(result *core.CoverageResult, unusedTypes []string, unusedFields map[string][]string, thresholdResults []core.ThresholdResult, comparison *core.CoverageComparison) error {
 	output := map[string]interface{}{
 		"coverage":     result,
 		"summary":      core.GetCoverageSummary(result),
 		"unusedTypes":  unusedTypes,
 		"unusedFields": unusedFields,
 	}
+	if len(thresholdResults) > 0 {
+		output["thresholds"] = thresholdResults
+	}
+	if comparison != nil {
+		output["comparison"] = comparison
+	}
 	
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
 }
 
-func outputCoverageText(result *core.CoverageResult, unusedTypes []string, unusedFields map[string][]string) error {
+// outputCoverageHTML writes the HTML coverage report to a directory
+func outputCoverageHTML(schema *core.Schema, documents []core.Document, result *core.CoverageResult, outDir string) error {
+	files, err := core.GenerateCoverageHTML(schema, documents, result)
+	if err != nil {
+		return fmt.Errorf("failed to generate html report: %w", err)
+	}
+	
+	for name, content := range files {
+		path := filepath.Join(outDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	fmt.Printf("📄 Coverage report written to %s\n", filepath.Join(outDir, "index.html"))
+	return nil
+}
+
+// outputCoverageSDL prints the schema with the coverage of each member as a trailing comment
+func outputCoverageSDL(schema *core.Schema, result *core.CoverageResult) error {
+	sdl, err := core.FormatCoverageSDL(schema, result)
+	if err != nil {
+		return fmt.Errorf("failed to annotate schema: %w", err)
+	}
+	fmt.Print(sdl)
+	return nil
+}
+
+func outputCoverageText(result *core.CoverageResult, unusedTypes []string, unusedFields map[string][]string, thresholdResults []core.ThresholdResult, comparison *core.CoverageComparison) error {
 	summary := core.GetCoverageSummary(result)
 	
 	// Print coverage summary
@@ -137,9 +341,21 @@ func outputCoverageText(result *core.CoverageResult, unusedTypes []string, unuse
 	fmt.Printf("===============================\n\n")
 	
 	fmt.Printf("📊 Coverage Summary:\n")
-	fmt.Printf("  Overall Coverage: %.2f%%\n", summary.OverallCoverage*100)
-	fmt.Printf("  Type Coverage:    %.2f%% (%d/%d)\n", summary.TypeCoverage*100, summary.CoveredTypes, summary.TotalTypes)
-	fmt.Printf("  Field Coverage:   %.2f%% (%d/%d)\n", summary.FieldCoverage*100, summary.CoveredFields, summary.TotalFields)
+	fmt.Printf("  Overall Coverage:     %.2f%%\n", summary.OverallCoverage*100)
+	fmt.Printf("  Type Coverage:        %.2f%% (%d/%d)\n", summary.TypeCoverage*100, summary.CoveredTypes, summary.TotalTypes)
+	fmt.Printf("  Field Coverage:       %.2f%% (%d/%d)\n", summary.FieldCoverage*100, summary.CoveredFields, summary.TotalFields)
+	if viper.GetBool("coverage.arguments") {
+		fmt.Printf("  Argument Coverage:    %.2f%% (%d/%d)\n", summary.ArgumentCoverage*100, summary.CoveredArguments, summary.TotalArguments)
+	}
+	if viper.GetBool("coverage.enum-values") {
+		fmt.Printf("  Enum Value Coverage:  %.2f%% (%d/%d)\n", summary.EnumValueCoverage*100, summary.CoveredEnumValues, summary.TotalEnumValues)
+	}
+	if viper.GetBool("coverage.input-fields") {
+		fmt.Printf("  Input Field Coverage: %.2f%% (%d/%d)\n", summary.InputFieldCoverage*100, summary.CoveredInputFields, summary.TotalInputFields)
+	}
+	if viper.GetString("coverage.usage-file") != "" {
+		fmt.Printf("  Fields With Traffic:  %d/%d (%d hits)\n", result.FieldsWithTraffic, result.TotalFields, result.TotalHits)
+	}
 	fmt.Println()
 	
 	// Check threshold
@@ -152,12 +368,27 @@ func outputCoverageText(result *core.CoverageResult, unusedTypes []string, unuse
 		fmt.Println()
 	}
 	
+	if len(thresholdResults) > 0 {
+		printThresholdResults(thresholdResults)
+	}
+	
+	if comparison != nil {
+		printCoverageComparison(comparison)
+	}
+	
 	// Show detailed coverage if requested
 	if viper.GetBool("coverage.show-details") {
 		fmt.Printf("📋 Detailed Coverage:\n")
 		fmt.Printf("====================\n")
 		
-		for typeName, typeCoverage := range result.Details {
+		typeNames := make([]string, 0, len(result.Details))
+		for typeName := range result.Details {
+			typeNames = append(typeNames, typeName)
+		}
+		sort.Strings(typeNames)
+		
+		for _, typeName := range typeNames {
+			typeCoverage := result.Details[typeName]
 			status := "❌"
 			if typeCoverage.Covered {
 				status = "✅"
@@ -170,14 +401,27 @@ func outputCoverageText(result *core.CoverageResult, unusedTypes []string, unuse
 			fmt.Println()
 			
 			if len(typeCoverage.Fields) > 0 {
-				for fieldName, covered := range typeCoverage.Fields {
+				fieldNames := make([]string, 0, len(typeCoverage.Fields))
+				for fieldName := range typeCoverage.Fields {
+					fieldNames = append(fieldNames, fieldName)
+				}
+				sort.Strings(fieldNames)
+				for _, fieldName := range fieldNames {
+					covered := typeCoverage.Fields[fieldName]
 					fieldStatus := "❌"
 					if covered {
 						fieldStatus = "✅"
 					}
-					fmt.Printf("  %s %s\n", fieldStatus, fieldName)
+					if typeCoverage.FieldHits != nil {
+						fmt.Printf("  %s %s (%d hits)\n", fieldStatus, fieldName, typeCoverage.FieldHits[fieldName])
+					} else {
+						fmt.Printf("  %s %s\n", fieldStatus, fieldName)
+					}
 				}
 			}
+			printCoverageMembers(typeCoverage.Arguments)
+			printCoverageMembers(typeCoverage.EnumValues)
+			printCoverageMembers(typeCoverage.InputFields)
 		}
 		fmt.Println()
 	}
@@ -204,6 +448,26 @@ func outputCoverageText(result *core.CoverageResult, unusedTypes []string, unuse
 			}
 			fmt.Println()
 		}
+		
+		printUnusedMembers("🗑️  Unused Arguments", result, func(t core.TypeCoverage) map[string]bool { return t.Arguments })
+		printUnusedMembers("🗑️  Unused Enum Values", result, func(t core.TypeCoverage) map[string]bool { return t.EnumValues })
+		printUnusedMembers("🗑️  Unused Input Fields", result, func(t core.TypeCoverage) map[string]bool { return t.InputFields })
+	}
+	
+	printAbstractCoverage(result.AbstractTypes)
+	
+	// Show fields no request selected when traffic is given
+	if viper.GetString("coverage.usage-file") != "" && len(result.ZeroTraffic) > 0 {
+		title := fmt.Sprintf("🧊 Fields With Zero Traffic (%d)", len(result.ZeroTraffic))
+		if days := viper.GetInt("coverage.traffic-days"); days > 0 {
+			title = fmt.Sprintf("🧊 Fields With Zero Traffic in %d Days (%d)", days, len(result.ZeroTraffic))
+		}
+		fmt.Printf("%s:\n", title)
+		fmt.Println(strings.Repeat("=", utf8.RuneCountInString(title)+1))
+		for _, field := range result.ZeroTraffic {
+			fmt.Printf("  • %s\n", field)
+		}
+		fmt.Println()
 	}
 	
 	// Generate coverage report
@@ -212,16 +476,150 @@ func outputCoverageText(result *core.CoverageResult, unusedTypes []string, unuse
 		fmt.Println("💡 Use --show-unused to see unused types and fields")
 	}
 	
-	// Check failure condition
-	if viper.GetBool("coverage.fail-on-threshold") && summary.OverallCoverage < threshold {
-		return fmt.Errorf("coverage %.2f%% is below threshold %.2f%%", summary.OverallCoverage*100, threshold*100)
-	}
-	
 	return nil
 }
 
 // Additional helper functions for coverage analysis
 
+// printAbstractCoverage prints the interface and union positions where fragments
+// handle some possible types but never others, with the selections missing them
+func printAbstractCoverage(positions []core.AbstractCoverage) {
+	var partial []core.AbstractCoverage
+	for _, position := range positions {
+		for _, selection := range position.Selections {
+			if len(selection.UnhandledTypes) > 0 {
+				partial = append(partial, position)
+				break
+			}
+		}
+	}
+	if len(partial) == 0 {
+		return
+	}
+	
+	title := fmt.Sprintf("🧩 Unhandled Possible Types (%d)", len(partial))
+	fmt.Printf("%s:\n", title)
+	fmt.Println(strings.Repeat("=", utf8.RuneCountInString(title)+1))
+	for _, position := range partial {
+		fmt.Printf("  %s (%s): handles %s", position.Position, position.Type, strings.Join(position.HandledTypes, ", "))
+		if len(position.UnhandledTypes) > 0 {
+			fmt.Printf("; never handles %s", strings.Join(position.UnhandledTypes, ", "))
+		}
+		fmt.Println()
+		for _, selection := range position.Selections {
+			if len(selection.UnhandledTypes) == 0 {
+				continue
+			}
+			location := selection.Source
+			if selection.Range != nil {
+				location = fmt.Sprintf("%s:%d:%d", selection.Source, selection.Range.Start.Line, selection.Range.Start.Column)
+			}
+			fmt.Printf("    • %s in %s misses %s\n", location, selection.Operation, strings.Join(selection.UnhandledTypes, ", "))
+		}
+	}
+	fmt.Println()
+}
+
+// printThresholdResults prints the outcome of each per-type threshold
+func printThresholdResults(thresholdResults []core.ThresholdResult) {
+	fmt.Printf("🎯 Type Thresholds:\n")
+	fmt.Printf("==================\n")
+	for _, evaluated := range thresholdResults {
+		switch {
+		case evaluated.Total == 0:
+			fmt.Printf("  ⚠️  %s matches no types\n", evaluated.Pattern)
+		case evaluated.Passed:
+			fmt.Printf("  ✅ %s: %.2f%% meets threshold %.2f%% (%d types)\n", evaluated.Pattern, evaluated.Coverage*100, evaluated.Threshold*100, len(evaluated.Types))
+		default:
+			fmt.Printf("  ❌ %s: %.2f%% is below threshold %.2f%% (%d types)\n", evaluated.Pattern, evaluated.Coverage*100, evaluated.Threshold*100, len(evaluated.Types))
+		}
+	}
+	fmt.Println()
+}
+
+// printCoverageComparison prints the changes in coverage since the baseline
+func printCoverageComparison(comparison *core.CoverageComparison) {
+	fmt.Printf("📈 Baseline Comparison:\n")
+	fmt.Printf("======================\n")
+	fmt.Printf("  Overall Coverage:     %.2f%% → %.2f%% (%+.2f%%)\n",
+		comparison.BaselineCoverage*100, comparison.Coverage*100, (comparison.Coverage-comparison.BaselineCoverage)*100)
+	
+	if len(comparison.NewlyCovered) > 0 {
+		fmt.Printf("\n  ✅ Newly Covered (%d):\n", len(comparison.NewlyCovered))
+		for _, coordinate := range comparison.NewlyCovered {
+			fmt.Printf("    • %s\n", coordinate)
+		}
+	}
+	if len(comparison.NewlyUncovered) > 0 {
+		fmt.Printf("\n  ❌ Newly Uncovered (%d):\n", len(comparison.NewlyUncovered))
+		for _, coordinate := range comparison.NewlyUncovered {
+			fmt.Printf("    • %s\n", coordinate)
+		}
+	}
+	if len(comparison.TypeDecreases) > 0 {
+		fmt.Printf("\n  📉 Type Coverage Decreased (%d):\n", len(comparison.TypeDecreases))
+		for _, change := range comparison.TypeDecreases {
+			fmt.Printf("    • %s: %.2f%% → %.2f%%\n", change.Type, change.Baseline*100, change.Coverage*100)
+		}
+	}
+	fmt.Println()
+	
+	if comparison.Regressed {
+		fmt.Printf("❌ Coverage decreased from baseline by more than %.2f%%\n", comparison.Tolerance*100)
+	} else {
+		fmt.Printf("✅ Coverage did not decrease from baseline\n")
+	}
+	fmt.Println()
+}
+
+// printCoverageMembers prints the coverage of the arguments, enum values or input fields of a type, sorted
+func printCoverageMembers(members map[string]bool) {
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	for _, name := range names {
+		status := "❌"
+		if members[name] {
+			status = "✅"
+		}
+		fmt.Printf("  %s %s\n", status, name)
+	}
+}
+
+// printUnusedMembers prints the members of one coverage dimension that no document uses, by type
+func printUnusedMembers(title string, result *core.CoverageResult, members func(core.TypeCoverage) map[string]bool) {
+	unused := make(map[string][]string)
+	var typeNames []string
+	for typeName, typeCoverage := range result.Details {
+		for name, covered := range members(typeCoverage) {
+			if !covered {
+				unused[typeName] = append(unused[typeName], name)
+			}
+		}
+		if len(unused[typeName]) > 0 {
+			typeNames = append(typeNames, typeName)
+		}
+	}
+	if len(typeNames) == 0 {
+		return
+	}
+	sort.Strings(typeNames)
+	
+	fmt.Printf("%s:\n", title)
+	fmt.Println(strings.Repeat("=", utf8.RuneCountInString(title)+1))
+	for _, typeName := range typeNames {
+		fmt.Printf("  %s:\n", typeName)
+		sort.Strings(unused[typeName])
+		for _, name := range unused[typeName] {
+			fmt.Printf("    • %s\n", name)
+		}
+	}
+	fmt.Println()
+}
+
 func printCoverageBar(coverage float64) string {
 	const barWidth = 20
 	filled := int(coverage * barWidth)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/bishnuag/graphql-inspector/pkg/core"
 	"github.com/bishnuag/graphql-inspector/pkg/loader"
@@ -27,6 +28,9 @@ Examples:
   # Compare with options
   graphql-inspector diff old-schema.graphql new-schema.graphql --ignore-descriptions
   
+  # Only check the parts of the schema we own
+  graphql-inspector diff old-schema.graphql new-schema.graphql --include "Billing*" --exclude "@internal"
+  
   # Output in JSON format
   graphql-inspector diff old-schema.graphql new-schema.graphql --json`,
 	Args: cobra.ExactArgs(2),
@@ -38,17 +42,29 @@ func init() {
 	
 	// Diff-specific flags
 	diffCmd.Flags().Bool("ignore-descriptions", false, "ignore description changes")
+	diffCmd.Flags().Bool("ignore-description-whitespace", false, "ignore description changes that only affect whitespace")
+	diffCmd.Flags().Bool("ignore-description-formatting", false, "ignore description changes that only affect whitespace or Markdown formatting")
 	diffCmd.Flags().Bool("ignore-directives", false, "ignore directive changes")
 	diffCmd.Flags().StringSlice("rules", []string{}, "custom rules to apply")
 	diffCmd.Flags().Bool("fail-on-breaking", false, "exit with non-zero code if breaking changes are found")
 	diffCmd.Flags().Bool("fail-on-dangerous", false, "exit with non-zero code if dangerous changes are found")
+	diffCmd.Flags().StringSlice("include", []string{}, "only report changes to coordinates matching these globs or @directives")
+	diffCmd.Flags().StringSlice("exclude", []string{}, "ignore changes to coordinates matching these globs or @directives")
+	diffCmd.Flags().Bool("downgrade-unreachable", false, "treat changes to types unreachable from the root types as non-breaking")
+	diffCmd.Flags().String("format", "text", "output format: text, markdown or json")
 	
 	// Bind flags to viper
 	viper.BindPFlag("diff.ignore-descriptions", diffCmd.Flags().Lookup("ignore-descriptions"))
+	viper.BindPFlag("diff.ignore-description-whitespace", diffCmd.Flags().Lookup("ignore-description-whitespace"))
+	viper.BindPFlag("diff.ignore-description-formatting", diffCmd.Flags().Lookup("ignore-description-formatting"))
 	viper.BindPFlag("diff.ignore-directives", diffCmd.Flags().Lookup("ignore-directives"))
 	viper.BindPFlag("diff.rules", diffCmd.Flags().Lookup("rules"))
 	viper.BindPFlag("diff.fail-on-breaking", diffCmd.Flags().Lookup("fail-on-breaking"))
 	viper.BindPFlag("diff.fail-on-dangerous", diffCmd.Flags().Lookup("fail-on-dangerous"))
+	viper.BindPFlag("diff.include", diffCmd.Flags().Lookup("include"))
+	viper.BindPFlag("diff.exclude", diffCmd.Flags().Lookup("exclude"))
+	viper.BindPFlag("diff.downgrade-unreachable", diffCmd.Flags().Lookup("downgrade-unreachable"))
+	viper.BindPFlag("diff.format", diffCmd.Flags().Lookup("format"))
 }
 
 func runDiff(cmd *cobra.Command, args []string) error {
@@ -72,9 +88,14 @@ func runDiff(cmd *cobra.Command, args []string) error {
 	
 	// Configure diff options
 	options := &core.DiffOptions{
-		IgnoreDescriptions: viper.GetBool("diff.ignore-descriptions"),
-		IgnoreDirectives:   viper.GetBool("diff.ignore-directives"),
-		CustomRules:        viper.GetStringSlice("diff.rules"),
+		IgnoreDescriptions:          viper.GetBool("diff.ignore-descriptions"),
+		IgnoreDescriptionWhitespace: viper.GetBool("diff.ignore-description-whitespace"),
+		IgnoreDescriptionFormatting: viper.GetBool("diff.ignore-description-formatting"),
+		IgnoreDirectives:            viper.GetBool("diff.ignore-directives"),
+		DowngradeUnreachable:        viper.GetBool("diff.downgrade-unreachable"),
+		CustomRules:                 viper.GetStringSlice("diff.rules"),
+		Include:                     viper.GetStringSlice("diff.include"),
+		Exclude:                     viper.GetStringSlice("diff.exclude"),
 	}
 	
 	// Compare schemas
@@ -83,17 +104,32 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to compare schemas: %w", err)
 	}
 	
+	// Types left behind with no path from the root types
+	orphans := core.FindOrphanTypes(newSchema, oldSchema)
+	
 	// Output results
+	format := viper.GetString("diff.format")
 	if viper.GetBool("json") {
-		return outputDiffJSON(changes)
-	} else {
-		return outputDiffText(changes)
+		format = "json"
+	}
+	
+	switch format {
+	case "json":
+		return outputDiffJSON(changes, orphans)
+	case "markdown", "md":
+		return outputDiffMarkdown(changes, orphans)
+	case "text", "":
+		return outputDiffText(changes, orphans)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
 	}
 }
 
-func outputDiffJSON(changes []core.Change) error {
+func outputDiffJSON(changes []core.Change, orphans []core.OrphanType) error {
 	output := map[string]interface{}{
 		"changes": changes,
+		"groups":  core.GroupChanges(changes),
+		"orphans": orphans,
 		"summary": calculateDiffSummary(changes),
 	}
 	
@@ -102,9 +138,10 @@ func outputDiffJSON(changes []core.Change) error {
 	return encoder.Encode(output)
 }
 
-func outputDiffText(changes []core.Change) error {
+func outputDiffText(changes []core.Change, orphans []core.OrphanType) error {
 	if len(changes) == 0 {
 		fmt.Println("✅ No changes detected")
+		printOrphanTypes(orphans)
 		return nil
 	}
 	
@@ -117,42 +154,124 @@ func outputDiffText(changes []core.Change) error {
 	fmt.Printf("  - %d non-breaking\n", summary.NonBreaking)
 	fmt.Println()
 	
-	// Group changes by type
-	breakingChanges := filterChangesByType(changes, core.ChangeTypeBreaking)
-	dangerousChanges := filterChangesByType(changes, core.ChangeTypeDangerous)
-	nonBreakingChanges := filterChangesByType(changes, core.ChangeTypeNonBreaking)
+	// Group changes by type, folding changes nested under a parent change
+	groups := core.GroupChanges(changes)
+	breakingChanges := filterGroupsByType(groups, core.ChangeTypeBreaking)
+	dangerousChanges := filterGroupsByType(groups, core.ChangeTypeDangerous)
+	nonBreakingChanges := filterGroupsByType(groups, core.ChangeTypeNonBreaking)
 	
 	// Print breaking changes
 	if len(breakingChanges) > 0 {
-		fmt.Printf("🔴 Breaking Changes (%d):\n", len(breakingChanges))
+		fmt.Printf("🔴 Breaking Changes (%d):\n", countGroupedChanges(breakingChanges))
 		fmt.Println("========================")
-		for _, change := range breakingChanges {
-			printChange(change)
+		for _, group := range breakingChanges {
+			printChangeGroup(group)
 		}
 		fmt.Println()
 	}
 	
 	// Print dangerous changes
 	if len(dangerousChanges) > 0 {
-		fmt.Printf("🟡 Dangerous Changes (%d):\n", len(dangerousChanges))
+		fmt.Printf("🟡 Dangerous Changes (%d):\n", countGroupedChanges(dangerousChanges))
 		fmt.Println("=========================")
-		for _, change := range dangerousChanges {
-			printChange(change)
+		for _, group := range dangerousChanges {
+			printChangeGroup(group)
 		}
 		fmt.Println()
 	}
 	
 	// Print non-breaking changes
 	if len(nonBreakingChanges) > 0 {
-		fmt.Printf("🟢 Non-Breaking Changes (%d):\n", len(nonBreakingChanges))
+		fmt.Printf("🟢 Non-Breaking Changes (%d):\n", countGroupedChanges(nonBreakingChanges))
 		fmt.Println("=============================")
-		for _, change := range nonBreakingChanges {
-			printChange(change)
+		for _, group := range nonBreakingChanges {
+			printChangeGroup(group)
+		}
+		fmt.Println()
+	}
+	
+	printOrphanTypes(orphans)
+	
+	return checkDiffFailures(summary)
+}
+
+func outputDiffMarkdown(changes []core.Change, orphans []core.OrphanType) error {
+	if len(changes) == 0 {
+		fmt.Println("✅ No changes detected")
+		fmt.Println()
+		printOrphanTypesMarkdown(orphans)
+		return nil
+	}
+	
+	summary := calculateDiffSummary(changes)
+	
+	fmt.Printf("## Schema Changes\n\n")
+	fmt.Printf("Found **%d** changes: %d breaking, %d dangerous, %d non-breaking.\n\n",
+		len(changes), summary.Breaking, summary.Dangerous, summary.NonBreaking)
+	
+	groups := core.GroupChanges(changes)
+	sections := []struct {
+		title      string
+		changeType core.ChangeType
+	}{
+		{"🔴 Breaking Changes", core.ChangeTypeBreaking},
+		{"🟡 Dangerous Changes", core.ChangeTypeDangerous},
+		{"🟢 Non-Breaking Changes", core.ChangeTypeNonBreaking},
+	}
+	
+	for _, section := range sections {
+		sectionGroups := filterGroupsByType(groups, section.changeType)
+		if len(sectionGroups) == 0 {
+			continue
+		}
+		fmt.Printf("### %s (%d)\n\n", section.title, countGroupedChanges(sectionGroups))
+		for _, group := range sectionGroups {
+			printChangeGroupMarkdown(group, "")
 		}
 		fmt.Println()
 	}
 	
-	// Check for failure conditions
+	printOrphanTypesMarkdown(orphans)
+	
+	return checkDiffFailures(summary)
+}
+
+// printOrphanTypes lists the types of the new schema that no root type leads to
+func printOrphanTypes(orphans []core.OrphanType) {
+	if len(orphans) == 0 {
+		return
+	}
+	
+	fmt.Printf("🧟 Orphan Types (%d):\n", len(orphans))
+	fmt.Println("===================")
+	for _, orphan := range orphans {
+		fmt.Printf("  %s (%s)", orphan.Name, strings.ToLower(orphan.Kind))
+		if orphan.New {
+			fmt.Printf(" - new")
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
+// printOrphanTypesMarkdown lists orphan types as a Markdown section
+func printOrphanTypesMarkdown(orphans []core.OrphanType) {
+	if len(orphans) == 0 {
+		return
+	}
+	
+	fmt.Printf("### 🧟 Orphan Types (%d)\n\n", len(orphans))
+	for _, orphan := range orphans {
+		fmt.Printf("- `%s` (%s)", orphan.Name, strings.ToLower(orphan.Kind))
+		if orphan.New {
+			fmt.Printf(" _new_")
+		}
+		fmt.Println()
+	}
+}
+
+// checkDiffFailures returns an error when the changes trip a --fail-on-* flag
+func checkDiffFailures(summary DiffSummary) error {
 	if viper.GetBool("diff.fail-on-breaking") && summary.Breaking > 0 {
 		return fmt.Errorf("breaking changes detected")
 	}
@@ -164,13 +283,98 @@ func outputDiffText(changes []core.Change) error {
 	return nil
 }
 
-func printChange(change core.Change) {
-	icon := getChangeIcon(change.Type)
-	fmt.Printf("  %s %s", icon, change.Message)
-	if change.Path != "" {
-		fmt.Printf(" (at %s)", change.Path)
+// printChangeGroupMarkdown prints a change as a list item, with nested changes as a nested list
+func printChangeGroupMarkdown(group core.ChangeGroup, indent string) {
+	fmt.Printf("%s- %s", indent, group.Message)
+	if group.Path != "" {
+		fmt.Printf(" (`%s`)", group.Path)
 	}
 	fmt.Println()
+	
+	if edits, ok := descriptionEdits(group.Change); ok {
+		fmt.Printf("%s  > %s\n", indent, renderTextEditsMarkdown(edits))
+	}
+	
+	for _, child := range group.Children {
+		printChangeGroupMarkdown(child, indent+"  ")
+	}
+}
+
+// printChangeGroup prints a change and folds the changes nested under it,
+// listing them individually in verbose mode
+func printChangeGroup(group core.ChangeGroup) {
+	icon := getChangeIcon(group.Type)
+	fmt.Printf("  %s %s", icon, group.Message)
+	if group.Path != "" {
+		fmt.Printf(" (at %s)", group.Path)
+	}
+	if related := group.Related(); related == 1 {
+		fmt.Printf(" and 1 related change")
+	} else if related > 1 {
+		fmt.Printf(" and %d related changes", related)
+	}
+	fmt.Println()
+	
+	if edits, ok := descriptionEdits(group.Change); ok {
+		fmt.Printf("      %s\n", renderTextEdits(edits))
+	}
+
+	if viper.GetBool("verbose") {
+		printNestedChanges(group.Children, "      ")
+	}
+}
+
+func printNestedChanges(groups []core.ChangeGroup, indent string) {
+	for _, group := range groups {
+		fmt.Printf("%s↳ %s", indent, group.Message)
+		if group.Path != "" {
+			fmt.Printf(" (at %s)", group.Path)
+		}
+		fmt.Println()
+		printNestedChanges(group.Children, indent+"  ")
+	}
+}
+
+// descriptionEdits returns a compact word-level diff for description changes
+func descriptionEdits(change core.Change) ([]core.TextEdit, bool) {
+	oldDesc, hasOld := change.Meta["oldDescription"].(string)
+	newDesc, hasNew := change.Meta["newDescription"].(string)
+	if !hasOld || !hasNew {
+		return nil, false
+	}
+	return core.CompactTextEdits(core.DiffWords(oldDesc, newDesc), 3), true
+}
+
+// renderTextEdits renders a word diff in git's --word-diff style
+func renderTextEdits(edits []core.TextEdit) string {
+	parts := make([]string, 0, len(edits))
+	for _, edit := range edits {
+		switch edit.Op {
+		case core.TextEditDelete:
+			parts = append(parts, "[-"+edit.Text+"-]")
+		case core.TextEditInsert:
+			parts = append(parts, "{+"+edit.Text+"+}")
+		default:
+			parts = append(parts, edit.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderTextEditsMarkdown renders a word diff with deletions struck through and insertions in bold
+func renderTextEditsMarkdown(edits []core.TextEdit) string {
+	parts := make([]string, 0, len(edits))
+	for _, edit := range edits {
+		switch edit.Op {
+		case core.TextEditDelete:
+			parts = append(parts, "~~"+edit.Text+"~~")
+		case core.TextEditInsert:
+			parts = append(parts, "**"+edit.Text+"**")
+		default:
+			parts = append(parts, edit.Text)
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
 func getChangeIcon(changeType core.ChangeType) string {
@@ -186,16 +390,26 @@ func getChangeIcon(changeType core.ChangeType) string {
 	}
 }
 
-func filterChangesByType(changes []core.Change, changeType core.ChangeType) []core.Change {
-	var filtered []core.Change
-	for _, change := range changes {
-		if change.Type == changeType {
-			filtered = append(filtered, change)
+func filterGroupsByType(groups []core.ChangeGroup, changeType core.ChangeType) []core.ChangeGroup {
+	var filtered []core.ChangeGroup
+	for _, group := range groups {
+		if group.Type == changeType {
+			filtered = append(filtered, group)
 		}
 	}
 	return filtered
 }
 
+// countGroupedChanges counts the changes a section lists: its groups and the
+// changes folded under them, whatever their severity
+func countGroupedChanges(groups []core.ChangeGroup) int {
+	count := 0
+	for _, group := range groups {
+		count += 1 + group.Related()
+	}
+	return count
+}
+
 func calculateDiffSummary(changes []core.Change) DiffSummary {
 	summary := DiffSummary{}
 	
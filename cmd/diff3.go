@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+	"github.com/bishnuag/graphql-inspector/pkg/loader"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// diff3Cmd represents the diff3 command
+var diff3Cmd = &cobra.Command{
+	Use:   "diff3 <base> <ours> <theirs>",
+	Short: "Detect conflicts between two schema branches and merge them",
+	Long: `Perform a three-way comparison of two GraphQL schemas that evolved from a common base.
+
+The diff3 command compares each branch against the base schema and reports coordinates
+that were changed incompatibly on both sides, such as a field added with different types
+or a field changed on one side inside a type removed on the other. When no conflicts are
+found, the merged schema is printed.
+
+Examples:
+  # Check two branches for conflicts and print the merged schema
+  graphql-inspector diff3 main.graphql feature-a.graphql feature-b.graphql
+
+  # Write the merged schema to a file
+  graphql-inspector diff3 main.graphql feature-a.graphql feature-b.graphql --output merged.graphql
+
+  # Output in JSON format
+  graphql-inspector diff3 main.graphql feature-a.graphql feature-b.graphql --json`,
+	Args: cobra.ExactArgs(3),
+	RunE: runDiff3,
+}
+
+func init() {
+	rootCmd.AddCommand(diff3Cmd)
+
+	// Diff3-specific flags
+	diff3Cmd.Flags().Bool("ignore-descriptions", false, "ignore description changes")
+	diff3Cmd.Flags().Bool("ignore-directives", false, "ignore directive changes")
+	diff3Cmd.Flags().StringP("output", "o", "", "write the merged schema to a file instead of stdout")
+
+	// Bind flags to viper
+	viper.BindPFlag("diff3.ignore-descriptions", diff3Cmd.Flags().Lookup("ignore-descriptions"))
+	viper.BindPFlag("diff3.ignore-directives", diff3Cmd.Flags().Lookup("ignore-directives"))
+	viper.BindPFlag("diff3.output", diff3Cmd.Flags().Lookup("output"))
+}
+
+func runDiff3(cmd *cobra.Command, args []string) error {
+	if viper.GetBool("verbose") {
+		fmt.Fprintf(os.Stderr, "Merging schemas: %s <- %s + %s\n", args[0], args[1], args[2])
+	}
+
+	// Load schemas
+	base, err := loader.LoadSchema(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load base schema: %w", err)
+	}
+
+	ours, err := loader.LoadSchema(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to load our schema: %w", err)
+	}
+
+	theirs, err := loader.LoadSchema(args[2])
+	if err != nil {
+		return fmt.Errorf("failed to load their schema: %w", err)
+	}
+
+	// Configure diff options
+	options := &core.DiffOptions{
+		IgnoreDescriptions: viper.GetBool("diff3.ignore-descriptions"),
+		IgnoreDirectives:   viper.GetBool("diff3.ignore-directives"),
+	}
+
+	// Merge schemas
+	result, err := core.MergeSchemas(base, ours, theirs, options)
+	if err != nil {
+		return fmt.Errorf("failed to merge schemas: %w", err)
+	}
+
+	// Output results
+	if viper.GetBool("json") {
+		if err := outputDiff3JSON(result); err != nil {
+			return err
+		}
+	} else if err := outputDiff3Text(result); err != nil {
+		return err
+	}
+
+	if len(result.Conflicts) > 0 {
+		return fmt.Errorf("%d merge conflicts detected", len(result.Conflicts))
+	}
+
+	return nil
+}
+
+func outputDiff3JSON(result *core.MergeResult) error {
+	output := map[string]interface{}{
+		"ours":      result.Ours,
+		"theirs":    result.Theirs,
+		"conflicts": result.Conflicts,
+		"sdl":       result.SDL,
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return err
+	}
+
+	// The JSON already carries the SDL, but --output still gets the schema file
+	if outputPath := viper.GetString("diff3.output"); outputPath != "" && len(result.Conflicts) == 0 {
+		if err := writeMergedSchema(outputPath, result); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Merged schema written to %s\n", outputPath)
+	}
+	return nil
+}
+
+func outputDiff3Text(result *core.MergeResult) error {
+	// Keep stdout clean for the merged schema unless it goes to a file
+	outputPath := viper.GetString("diff3.output")
+	status := os.Stdout
+	if outputPath == "" {
+		status = os.Stderr
+	}
+
+	fmt.Fprintf(status, "Ours:   %d changes against base\n", len(result.Ours))
+	fmt.Fprintf(status, "Theirs: %d changes against base\n", len(result.Theirs))
+	fmt.Fprintln(status)
+
+	if len(result.Conflicts) > 0 {
+		fmt.Fprintf(status, "❌ Merge Conflicts (%d):\n", len(result.Conflicts))
+		fmt.Fprintln(status, "=======================")
+		for _, conflict := range result.Conflicts {
+			fmt.Fprintf(status, "  💥 %s (at %s)\n", conflict.Message, conflict.Path)
+			for _, change := range conflict.Ours {
+				fmt.Fprintf(status, "      ours:   %s\n", describeMergeChange(change))
+			}
+			for _, change := range conflict.Theirs {
+				fmt.Fprintf(status, "      theirs: %s\n", describeMergeChange(change))
+			}
+		}
+		fmt.Fprintln(status)
+		return nil
+	}
+
+	fmt.Fprintln(status, "✅ No conflicts detected")
+
+	if outputPath == "" {
+		fmt.Print(result.SDL)
+		return nil
+	}
+
+	if err := writeMergedSchema(outputPath, result); err != nil {
+		return err
+	}
+	fmt.Fprintf(status, "Merged schema written to %s\n", outputPath)
+
+	return nil
+}
+
+// writeMergedSchema writes the merged SDL to a file
+func writeMergedSchema(outputPath string, result *core.MergeResult) error {
+	if err := os.WriteFile(outputPath, []byte(result.SDL), 0644); err != nil {
+		return fmt.Errorf("failed to write merged schema: %w", err)
+	}
+	return nil
+}
+
+// describeMergeChange adds the introduced type to a change message, since two
+// conflicting additions otherwise read the same
+func describeMergeChange(change core.Change) string {
+	for _, key := range []string{"fieldType", "argType"} {
+		if t, ok := change.Meta[key]; ok {
+			return fmt.Sprintf("%s as %v", change.Message, t)
+		}
+	}
+	return change.Message
+}
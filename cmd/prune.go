@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+	"github.com/bishnuag/graphql-inspector/pkg/loader"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune <schema> <documents>",
+	Short: "Remove the parts of a schema no document uses",
+	Long: `Print a schema without the parts that no document uses.
+
+The prune command removes unused fields, arguments, enum values and input fields,
+then the types left unreachable from the root types. Anything carrying the keep
+directive, or matching an allowlist pattern, is kept along with everything nested
+under it. With --deprecate, unused fields and enum values are marked
+@deprecated(reason: "unused") instead, so they can be removed after a grace period.
+
+Examples:
+  # Print the pruned schema
+  graphql-inspector prune schema.graphql "queries/**/*.graphql"
+
+  # Keep types and members marked @keep or under Admin*, and write to a file
+  graphql-inspector prune schema.graphql queries/ --allow "Admin*" --output pruned.graphql
+
+  # Deprecate instead of removing
+  graphql-inspector prune schema.graphql queries/ --deprecate --reason "unused since 2024-06"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPrune,
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	// Prune-specific flags
+	pruneCmd.Flags().String("keep-directive", "keep", "directive marking types and members to keep")
+	pruneCmd.Flags().StringSlice("allow", []string{}, "coordinate globs or @directives to keep")
+	pruneCmd.Flags().Bool("deprecate", false, "mark unused fields and enum values @deprecated instead of removing them")
+	pruneCmd.Flags().String("reason", "unused", "deprecation reason used with --deprecate")
+	pruneCmd.Flags().StringP("output", "o", "", "write the pruned schema to a file instead of stdout")
+
+	// Bind flags to viper
+	viper.BindPFlag("prune.keep-directive", pruneCmd.Flags().Lookup("keep-directive"))
+	viper.BindPFlag("prune.allow", pruneCmd.Flags().Lookup("allow"))
+	viper.BindPFlag("prune.deprecate", pruneCmd.Flags().Lookup("deprecate"))
+	viper.BindPFlag("prune.reason", pruneCmd.Flags().Lookup("reason"))
+	viper.BindPFlag("prune.output", pruneCmd.Flags().Lookup("output"))
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	schemaPath := args[0]
+	documentsPattern := args[1]
+
+	if viper.GetBool("verbose") {
+		fmt.Fprintf(os.Stderr, "Pruning schema: %s using documents: %s\n", schemaPath, documentsPattern)
+	}
+
+	// Load schema
+	schema, err := loader.LoadSchema(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to load schema: %w", err)
+	}
+
+	// Load documents
+	documents, err := loader.LoadDocuments(documentsPattern)
+	if err != nil {
+		return fmt.Errorf("failed to load documents: %w", err)
+	}
+
+	if len(documents) == 0 {
+		// Without documents everything would look unused
+		return fmt.Errorf("no documents found matching pattern: %s", documentsPattern)
+	}
+
+	options := &core.PruneOptions{
+		KeepDirective:     viper.GetString("prune.keep-directive"),
+		Allowlist:         viper.GetStringSlice("prune.allow"),
+		Deprecate:         viper.GetBool("prune.deprecate"),
+		DeprecationReason: viper.GetString("prune.reason"),
+	}
+
+	result, err := core.PruneSchema(schema, documents, options)
+	if err != nil {
+		return fmt.Errorf("failed to prune schema: %w", err)
+	}
+
+	// Output results
+	if viper.GetBool("json") {
+		return outputPruneJSON(result)
+	}
+	return outputPruneText(result)
+}
+
+func outputPruneJSON(result *core.PruneResult) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+
+	// The JSON already carries the SDL, but --output still gets the schema file
+	if outputPath := viper.GetString("prune.output"); outputPath != "" {
+		if err := writePrunedSchema(outputPath, result); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Pruned schema written to %s\n", outputPath)
+	}
+	return nil
+}
+
+func outputPruneText(result *core.PruneResult) error {
+	// Keep stdout clean for the pruned schema unless it goes to a file
+	outputPath := viper.GetString("prune.output")
+	status := os.Stdout
+	if outputPath == "" {
+		status = os.Stderr
+	}
+
+	printPruneList(status, "✂️  Removed", result.Removed)
+	printPruneList(status, "⚠️  Deprecated", result.Deprecated)
+	printPruneList(status, "🛡️  Kept", result.Kept)
+	if len(result.Removed) == 0 && len(result.Deprecated) == 0 {
+		fmt.Fprintln(status, "✅ Nothing to prune")
+		fmt.Fprintln(status)
+	}
+
+	if outputPath == "" {
+		fmt.Print(result.SDL)
+		return nil
+	}
+
+	if err := writePrunedSchema(outputPath, result); err != nil {
+		return err
+	}
+	fmt.Fprintf(status, "Pruned schema written to %s\n", outputPath)
+
+	return nil
+}
+
+// writePrunedSchema writes the pruned SDL to a file
+func writePrunedSchema(outputPath string, result *core.PruneResult) error {
+	if err := os.WriteFile(outputPath, []byte(result.SDL), 0644); err != nil {
+		return fmt.Errorf("failed to write pruned schema: %w", err)
+	}
+	return nil
+}
+
+// printPruneList prints the coordinates pruning removed, deprecated or kept
+func printPruneList(status *os.File, title string, coordinates []string) {
+	if len(coordinates) == 0 {
+		return
+	}
+
+	fmt.Fprintf(status, "%s (%d):\n", title, len(coordinates))
+	fmt.Fprintln(status, "====================")
+	for _, coordinate := range coordinates {
+		fmt.Fprintf(status, "  • %s\n", coordinate)
+	}
+	fmt.Fprintln(status)
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/bishnuag/graphql-inspector/pkg/core"
 	"github.com/bishnuag/graphql-inspector/pkg/loader"
@@ -27,6 +29,18 @@ Examples:
   # Validate with custom limits
   graphql-inspector validate queries/ schema.graphql --max-depth 10 --max-tokens 500
   
+  # Don't count Relay connection wrappers towards depth
+  graphql-inspector validate queries/ schema.graphql --max-depth 5 --ignore-depth-fields edges,node
+  
+  # Enable extra rules, downgrading one to a warning
+  graphql-inspector validate queries/ schema.graphql --rules no-anonymous-operations,no-introspection=warning
+  
+  # Also warn about operations duplicated under different names
+  graphql-inspector validate "queries/**/*.graphql" schema.graphql --detect-identical-operations
+  
+  # Check variables against the operation (also read from getUser.variables.json sidecars)
+  graphql-inspector validate getUser.graphql schema.graphql --variables fixtures/getUser.json
+  
   # Find deprecated field usage
   graphql-inspector validate queries/ schema.graphql --check-deprecated`,
 	Args: cobra.ExactArgs(2),
@@ -38,20 +52,32 @@ func init() {
 	
 	// Validation-specific flags
 	validateCmd.Flags().Int("max-depth", 15, "maximum query depth allowed")
+	validateCmd.Flags().StringSlice("ignore-depth-fields", []string{}, "field names that don't count towards query depth (e.g. edges,node)")
 	validateCmd.Flags().Int("max-tokens", 1000, "maximum tokens allowed in a query")
 	validateCmd.Flags().Int("max-aliases", 15, "maximum aliases allowed in a query")
 	validateCmd.Flags().Int("max-complexity", 1000, "maximum query complexity allowed")
+	validateCmd.Flags().StringToInt("type-cost", map[string]int{}, "cost of selecting a type, overriding the default of 1 for composite types (e.g. User=2)")
+	validateCmd.Flags().Int("default-list-size", 10, "list size assumed when a field has no slicing argument or @listSize")
 	validateCmd.Flags().Bool("check-deprecated", false, "check for deprecated field usage")
-	validateCmd.Flags().StringSlice("rules", []string{}, "custom validation rules")
+	validateCmd.Flags().Int("max-root-fields", 10, "maximum root fields per operation (max-root-fields rule)")
+	validateCmd.Flags().StringSlice("rules", []string{}, "validation rules to enable, optionally with a severity (e.g. no-introspection,max-root-fields=warning)")
+	validateCmd.Flags().String("variables", "", "JSON file of variables for documents without a .variables.json sidecar")
+	validateCmd.Flags().Bool("detect-identical-operations", false, "warn about differently named operations that select the same fields")
 	validateCmd.Flags().Bool("fail-on-error", true, "exit with non-zero code if validation errors are found")
 	
 	// Bind flags to viper
 	viper.BindPFlag("validate.max-depth", validateCmd.Flags().Lookup("max-depth"))
+	viper.BindPFlag("validate.ignore-depth-fields", validateCmd.Flags().Lookup("ignore-depth-fields"))
 	viper.BindPFlag("validate.max-tokens", validateCmd.Flags().Lookup("max-tokens"))
 	viper.BindPFlag("validate.max-aliases", validateCmd.Flags().Lookup("max-aliases"))
 	viper.BindPFlag("validate.max-complexity", validateCmd.Flags().Lookup("max-complexity"))
+	viper.BindPFlag("validate.type-cost", validateCmd.Flags().Lookup("type-cost"))
+	viper.BindPFlag("validate.default-list-size", validateCmd.Flags().Lookup("default-list-size"))
 	viper.BindPFlag("validate.check-deprecated", validateCmd.Flags().Lookup("check-deprecated"))
+	viper.BindPFlag("validate.max-root-fields", validateCmd.Flags().Lookup("max-root-fields"))
 	viper.BindPFlag("validate.rules", validateCmd.Flags().Lookup("rules"))
+	viper.BindPFlag("validate.variables", validateCmd.Flags().Lookup("variables"))
+	viper.BindPFlag("validate.detect-identical-operations", validateCmd.Flags().Lookup("detect-identical-operations"))
 	viper.BindPFlag("validate.fail-on-error", validateCmd.Flags().Lookup("fail-on-error"))
 }
 
@@ -84,13 +110,29 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Found %d documents to validate\n", len(documents))
 	}
 	
+	// Variables given on the command line apply to documents without a sidecar file
+	if variablesPath := viper.GetString("validate.variables"); variablesPath != "" {
+		variables, err := loader.LoadVariables(variablesPath)
+		if err != nil {
+			return fmt.Errorf("failed to load variables: %w", err)
+		}
+		for i := range documents {
+			if documents[i].Variables == nil {
+				documents[i].Variables = variables
+			}
+		}
+	}
+	
 	// Configure validation options
 	options := &core.ValidateOptions{
 		Schema:      schema,
-		MaxDepth:    viper.GetInt("validate.max-depth"),
-		MaxTokens:   viper.GetInt("validate.max-tokens"),
-		MaxAliases:  viper.GetInt("validate.max-aliases"),
-		CustomRules: viper.GetStringSlice("validate.rules"),
+		MaxDepth:          viper.GetInt("validate.max-depth"),
+		IgnoreDepthFields: viper.GetStringSlice("validate.ignore-depth-fields"),
+		MaxTokens:         viper.GetInt("validate.max-tokens"),
+		MaxAliases:        viper.GetInt("validate.max-aliases"),
+		MaxRootFields:     viper.GetInt("validate.max-root-fields"),
+		CustomRules:       viper.GetStringSlice("validate.rules"),
+		DetectIdenticalOperations: viper.GetBool("validate.detect-identical-operations"),
 	}
 	
 	// Validate documents
@@ -100,7 +142,7 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	}
 	
 	// Check for deprecated usage if requested
-	var deprecatedUsage []core.DeprecatedUsage
+	var deprecatedUsage []core.Diagnostic
 	if viper.GetBool("validate.check-deprecated") {
 		deprecatedUsage, err = core.FindDeprecatedUsage(schema, documents)
 		if err != nil {
@@ -112,7 +154,11 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	var complexityResults []core.ComplexityResult
 	maxComplexity := viper.GetInt("validate.max-complexity")
 	if maxComplexity > 0 {
-		complexityResults, err = core.ValidateOperationComplexity(schema, documents, maxComplexity)
+		costOptions := &core.CostOptions{
+			TypeCosts:       typeCostsFromConfig(schema),
+			DefaultListSize: viper.GetInt("validate.default-list-size"),
+		}
+		complexityResults, err = core.ValidateOperationComplexity(schema, documents, maxComplexity, costOptions)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to check complexity: %v\n", err)
 		}
@@ -126,7 +172,7 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	}
 }
 
-func outputValidationJSON(results []core.ValidationResult, deprecated []core.DeprecatedUsage, complexity []core.ComplexityResult) error {
+func outputValidationJSON(results []core.ValidationResult, deprecated []core.Diagnostic, complexity []core.ComplexityResult) error {
 	output := map[string]interface{}{
 		"results":    results,
 		"summary":    calculateValidationSummary(results),
@@ -139,7 +185,7 @@ func outputValidationJSON(results []core.ValidationResult, deprecated []core.Dep
 	return encoder.Encode(output)
 }
 
-func outputValidationText(results []core.ValidationResult, deprecated []core.DeprecatedUsage, complexity []core.ComplexityResult) error {
+func outputValidationText(results []core.ValidationResult, deprecated []core.Diagnostic, complexity []core.ComplexityResult) error {
 	summary := calculateValidationSummary(results)
 	
 	// Print summary
@@ -156,11 +202,11 @@ func outputValidationText(results []core.ValidationResult, deprecated []core.Dep
 		fmt.Printf("❌ Validation Errors:\n")
 		fmt.Println("====================")
 		
-		for i, result := range results {
+		for _, result := range results {
 			if !result.IsValid {
-				fmt.Printf("Document %d:\n", i+1)
-				for _, error := range result.Errors {
-					fmt.Printf("  • %s\n", error)
+				fmt.Printf("%s:\n", result.Source)
+				for _, diagnostic := range result.Diagnostics {
+					printDiagnostic(diagnostic)
 				}
 				fmt.Println()
 			}
@@ -172,7 +218,7 @@ func outputValidationText(results []core.ValidationResult, deprecated []core.Dep
 		fmt.Printf("⚠️  Deprecated Usage (%d):\n", len(deprecated))
 		fmt.Println("========================")
 		for _, usage := range deprecated {
-			fmt.Printf("  • %s in %s (%s)\n", usage.Field, usage.Source, usage.Reason)
+			printDiagnostic(usage)
 		}
 		fmt.Println()
 	}
@@ -187,6 +233,12 @@ func outputValidationText(results []core.ValidationResult, deprecated []core.Dep
 				status = "❌"
 			}
 			fmt.Printf("  %s %s: %d (in %s)\n", status, result.Operation, result.Complexity, result.Source)
+			if !result.IsValid || viper.GetBool("verbose") {
+				printCostBreakdown(result.Breakdown, "      ")
+			}
+			if result.Diagnostic != nil && result.Diagnostic.Suggestion != "" {
+				fmt.Printf("      💡 %s\n", result.Diagnostic.Suggestion)
+			}
 		}
 		fmt.Println()
 	}
@@ -205,6 +257,61 @@ func outputValidationText(results []core.ValidationResult, deprecated []core.Dep
 	return nil
 }
 
+// printDiagnostic prints a diagnostic with its location, rule and suggestion
+func printDiagnostic(diagnostic core.Diagnostic) {
+	location := diagnostic.Source
+	if diagnostic.Range != nil {
+		location = fmt.Sprintf("%s:%d:%d", diagnostic.Source, diagnostic.Range.Start.Line, diagnostic.Range.Start.Column)
+	}
+	fmt.Printf("  • %s: %s [%s]\n", location, diagnostic.Message, diagnostic.RuleID)
+	if diagnostic.Suggestion != "" {
+		fmt.Printf("    💡 %s\n", diagnostic.Suggestion)
+	}
+}
+
+// printCostBreakdown prints the cost of each field selection as a tree
+func printCostBreakdown(nodes []core.CostNode, indent string) {
+	for _, node := range nodes {
+		if node.Cost == 0 {
+			continue
+		}
+		fmt.Printf("%s%s: %d", indent, node.Path, node.Cost)
+		if node.Multiplier != 1 {
+			fmt.Printf(" (%d × %s)", node.Multiplier, node.Coordinate)
+		}
+		fmt.Println()
+		printCostBreakdown(node.Children, indent+"  ")
+	}
+}
+
+// typeCostsFromConfig reads per-type costs from --type-cost or the validate.type-cost config map.
+// Viper lowercases config keys, so they are matched to the schema's type names case-insensitively.
+func typeCostsFromConfig(schema *core.Schema) map[string]int {
+	typeNames := make(map[string]string)
+	for name := range schema.Schema.TypeMap() {
+		typeNames[strings.ToLower(name)] = name
+	}
+	
+	costs := make(map[string]int)
+	for key, value := range viper.GetStringMap("validate.type-cost") {
+		typeName, exists := typeNames[strings.ToLower(key)]
+		if !exists {
+			typeName = key
+		}
+		switch value := value.(type) {
+		case int:
+			costs[typeName] = value
+		case float64:
+			costs[typeName] = int(value)
+		case string:
+			if cost, err := strconv.Atoi(value); err == nil {
+				costs[typeName] = cost
+			}
+		}
+	}
+	return costs
+}
+
 func calculateValidationSummary(results []core.ValidationResult) ValidationSummary {
 	summary := ValidationSummary{
 		Total: len(results),
@@ -215,7 +322,11 @@ func calculateValidationSummary(results []core.ValidationResult) ValidationSumma
 			summary.Valid++
 		} else {
 			summary.Invalid++
-			summary.TotalErrors += len(result.Errors)
+			for _, diagnostic := range result.Diagnostics {
+				if diagnostic.Severity == core.SeverityError {
+					summary.TotalErrors++
+				}
+			}
 		}
 	}
 	
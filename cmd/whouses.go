@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+	"github.com/bishnuag/graphql-inspector/pkg/loader"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// whoUsesCmd represents the who-uses command
+var whoUsesCmd = &cobra.Command{
+	Use:   "who-uses <coordinate> <documents> <schema>",
+	Short: "List the documents and operations using a schema coordinate",
+	Long: `List the documents and operations that use a schema coordinate.
+
+The who-uses command finds every operation selecting a field, passing an argument,
+sending an enum value or filling in an input field, with the location of each use.
+Fragments are attributed to the operations spreading them. Given a type name, it
+lists the usages of each of the type's members.
+
+Examples:
+  # Find who selects a field before deprecating it
+  graphql-inspector who-uses User.email "queries/**/*.graphql" schema.graphql
+
+  # Arguments, enum values and input fields use the same coordinates
+  graphql-inspector who-uses "Query.users(first:)" queries/ schema.graphql
+  graphql-inspector who-uses Role.ADMIN queries/ schema.graphql
+
+  # Everything used on a type, in JSON format
+  graphql-inspector who-uses User queries/ schema.graphql --json`,
+	Args: cobra.ExactArgs(3),
+	RunE: runWhoUses,
+}
+
+func init() {
+	rootCmd.AddCommand(whoUsesCmd)
+}
+
+func runWhoUses(cmd *cobra.Command, args []string) error {
+	coordinate := args[0]
+	documentsPattern := args[1]
+	schemaPath := args[2]
+
+	if viper.GetBool("verbose") {
+		fmt.Fprintf(os.Stderr, "Finding usages of %s in documents: %s against schema: %s\n", coordinate, documentsPattern, schemaPath)
+	}
+
+	// Load schema
+	schema, err := loader.LoadSchema(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to load schema: %w", err)
+	}
+
+	// Load documents
+	documents, err := loader.LoadDocuments(documentsPattern)
+	if err != nil {
+		return fmt.Errorf("failed to load documents: %w", err)
+	}
+
+	if len(documents) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: No documents found matching pattern: %s\n", documentsPattern)
+		return nil
+	}
+
+	usages, err := core.WhoUses(schema, documents, coordinate)
+	if err != nil {
+		return err
+	}
+
+	// Output results
+	if viper.GetBool("json") {
+		return outputWhoUsesJSON(coordinate, usages)
+	}
+	outputWhoUsesText(coordinate, usages)
+	return nil
+}
+
+func outputWhoUsesJSON(coordinate string, usages map[string][]core.CoordinateUsage) error {
+	output := map[string]interface{}{
+		"coordinate": coordinate,
+		"usages":     usages,
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func outputWhoUsesText(coordinate string, usages map[string][]core.CoordinateUsage) {
+	if len(usages) == 0 {
+		fmt.Printf("✅ No operations use %s\n", coordinate)
+		return
+	}
+
+	coordinates := make([]string, 0, len(usages))
+	for used := range usages {
+		coordinates = append(coordinates, used)
+	}
+	sort.Strings(coordinates)
+
+	for _, used := range coordinates {
+		fmt.Printf("👥 %s (%d usages):\n", used, len(usages[used]))
+		fmt.Println("====================")
+		for _, usage := range usages[used] {
+			location := usage.Source
+			if usage.Range != nil {
+				location = fmt.Sprintf("%s:%d:%d", usage.Source, usage.Range.Start.Line, usage.Range.Start.Column)
+			}
+			fmt.Printf("  • %s in %s\n", location, usage.Operation)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+// abstractRecorder collects the possible types handled at each field returning
+// an interface or union
+type abstractRecorder struct {
+	schema    *graphql.Schema
+	positions map[string]*abstractPosition
+}
+
+// abstractPosition accumulates the selections of one field returning an abstract type
+type abstractPosition struct {
+	abstract   graphql.Abstract
+	selections []CoordinateUsage
+	handled    []map[string]bool
+	index      map[string]int
+}
+
+// newAbstractRecorder creates an empty abstract type recorder
+func newAbstractRecorder(schema *graphql.Schema) *abstractRecorder {
+	return &abstractRecorder{
+		schema:    schema,
+		positions: make(map[string]*abstractPosition),
+	}
+}
+
+// record adds the types handled by a selection, merging selections of the same
+// field node made more than once by an operation
+func (r *abstractRecorder) record(parent graphql.Type, fieldDef *graphql.FieldDefinition, abstract graphql.Abstract, handled map[string]bool, usage CoordinateUsage) {
+	coordinate := parent.Name() + "." + fieldDef.Name
+	position, exists := r.positions[coordinate]
+	if !exists {
+		position = &abstractPosition{abstract: abstract, index: make(map[string]int)}
+		r.positions[coordinate] = position
+	}
+
+	key := usage.Source + "\x00" + usage.Operation
+	if usage.Range != nil {
+		key += fmt.Sprintf("\x00%d:%d", usage.Range.Start.Line, usage.Range.Start.Column)
+	}
+	if i, seen := position.index[key]; seen {
+		for name := range handled {
+			position.handled[i][name] = true
+		}
+		return
+	}
+	position.index[key] = len(position.selections)
+	position.selections = append(position.selections, usage)
+	position.handled = append(position.handled, handled)
+}
+
+// results returns the recorded positions sorted by coordinate, with their
+// selections sorted by location
+func (r *abstractRecorder) results() []AbstractCoverage {
+	coordinates := make([]string, 0, len(r.positions))
+	for coordinate := range r.positions {
+		coordinates = append(coordinates, coordinate)
+	}
+	sort.Strings(coordinates)
+
+	var results []AbstractCoverage
+	for _, coordinate := range coordinates {
+		position := r.positions[coordinate]
+		var possible []string
+		for _, object := range r.schema.PossibleTypes(position.abstract) {
+			possible = append(possible, object.Name())
+		}
+		sort.Strings(possible)
+
+		coverage := AbstractCoverage{
+			Position:      coordinate,
+			Type:          position.abstract.Name(),
+			PossibleTypes: possible,
+		}
+		order := make([]int, len(position.selections))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return compareUsages(position.selections[order[i]], position.selections[order[j]]) < 0
+		})
+
+		union := make(map[string]bool)
+		for _, i := range order {
+			usage := position.selections[i]
+			selection := AbstractSelection{
+				Source:       usage.Source,
+				Operation:    usage.Operation,
+				Range:        usage.Range,
+				HandledTypes: partitionTypes(possible, position.handled[i], true),
+			}
+			if len(selection.HandledTypes) > 0 {
+				selection.UnhandledTypes = partitionTypes(possible, position.handled[i], false)
+			}
+			for name := range position.handled[i] {
+				union[name] = true
+			}
+			coverage.Selections = append(coverage.Selections, selection)
+		}
+
+		coverage.HandledTypes = partitionTypes(possible, union, true)
+		coverage.UnhandledTypes = []string{}
+		if len(coverage.HandledTypes) > 0 {
+			coverage.UnhandledTypes = partitionTypes(possible, union, false)
+		}
+		results = append(results, coverage)
+	}
+	return results
+}
+
+// partitionTypes returns the possible types that are, or aren't, handled
+func partitionTypes(possible []string, handled map[string]bool, wantHandled bool) []string {
+	types := []string{}
+	for _, name := range possible {
+		if handled[name] == wantHandled {
+			types = append(types, name)
+		}
+	}
+	return types
+}
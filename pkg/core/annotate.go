@@ -0,0 +1,297 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// CoverageLine is a line of schema SDL annotated with the coverage of the schema
+// coordinate it defines, if any
+type CoverageLine struct {
+	Text string `json:"text"`
+	// Coordinate is the type, field, argument, enum value or input field defined
+	// on the line, e.g. "User", "User.email" or "Query.users(first:)"
+	Coordinate string `json:"coordinate,omitempty"`
+	// Tracked is set when coverage was measured for the coordinate; arguments,
+	// enum values and input fields can be excluded from coverage
+	Tracked bool `json:"tracked"`
+	Covered bool `json:"covered"`
+	// Hits is the traffic-weighted hit count of a type or field, when coverage is weighted
+	Hits *int64 `json:"hits,omitempty"`
+}
+
+// AnnotateSchemaCoverage prints a schema's SDL line by line, in definition order,
+// annotating each line that defines a coordinate with its coverage
+func AnnotateSchemaCoverage(schema *Schema, result *CoverageResult) ([]CoverageLine, error) {
+	doc, err := parseSchemaSDL(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	annotator := &coverageAnnotator{result: result}
+	for i, def := range doc.Definitions {
+		if i > 0 {
+			annotator.add("", "")
+		}
+		annotator.definition(def)
+	}
+	return annotator.lines, nil
+}
+
+// coverageAnnotator accumulates annotated SDL lines
+type coverageAnnotator struct {
+	result *CoverageResult
+	lines  []CoverageLine
+}
+
+// add appends printed text, annotating each of its lines with a coordinate
+func (a *coverageAnnotator) add(text, coordinate string) {
+	for _, line := range strings.Split(text, "\n") {
+		a.lines = append(a.lines, a.annotate(line, coordinate))
+	}
+}
+
+// annotate looks up the coverage of a coordinate, e.g. "User.posts(first:)"
+func (a *coverageAnnotator) annotate(text, coordinate string) CoverageLine {
+	line := CoverageLine{Text: text, Coordinate: coordinate}
+	if coordinate == "" {
+		return line
+	}
+
+	typeName, member, hasMember := strings.Cut(coordinate, ".")
+	typeCoverage, exists := a.result.Details[typeName]
+	if !exists {
+		return line
+	}
+	if !hasMember {
+		line.Tracked, line.Covered = true, typeCoverage.Covered
+		if typeCoverage.FieldHits != nil {
+			hits := typeCoverage.Hits
+			line.Hits = &hits
+		}
+		return line
+	}
+
+	for _, members := range []map[string]bool{typeCoverage.Fields, typeCoverage.Arguments, typeCoverage.EnumValues, typeCoverage.InputFields} {
+		if covered, tracked := members[member]; tracked {
+			line.Tracked, line.Covered = true, covered
+			break
+		}
+	}
+	if hits, ok := typeCoverage.FieldHits[member]; ok {
+		line.Hits = &hits
+	}
+	return line
+}
+
+// definition annotates a type definition and its members
+func (a *coverageAnnotator) definition(def ast.Node) {
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		a.description(def.Description, "")
+		a.header("type "+def.Name.Value+printImplements(def.Interfaces)+printDirectives(def.Directives), def.Name.Value, len(def.Fields) > 0)
+		a.fields(def.Name.Value, def.Fields)
+	case *ast.TypeExtensionDefinition:
+		if def.Definition != nil {
+			name := def.Definition.Name.Value
+			a.header("extend type "+name+printImplements(def.Definition.Interfaces)+printDirectives(def.Definition.Directives), name, len(def.Definition.Fields) > 0)
+			a.fields(name, def.Definition.Fields)
+		}
+	case *ast.InterfaceDefinition:
+		a.description(def.Description, "")
+		a.header("interface "+def.Name.Value+printDirectives(def.Directives), def.Name.Value, len(def.Fields) > 0)
+		a.fields(def.Name.Value, def.Fields)
+	case *ast.EnumDefinition:
+		a.description(def.Description, "")
+		a.header("enum "+def.Name.Value+printDirectives(def.Directives), def.Name.Value, len(def.Values) > 0)
+		for _, value := range def.Values {
+			a.member(printEnumValue(value), def.Name.Value+"."+value.Name.Value)
+		}
+		a.footer(len(def.Values) > 0)
+	case *ast.InputObjectDefinition:
+		a.description(def.Description, "")
+		a.header("input "+def.Name.Value+printDirectives(def.Directives), def.Name.Value, len(def.Fields) > 0)
+		for _, field := range def.Fields {
+			a.member(printInputValue(field, "  "), def.Name.Value+"."+field.Name.Value)
+		}
+		a.footer(len(def.Fields) > 0)
+	case *ast.DirectiveDefinition, *ast.SchemaDefinition:
+		a.add(printDefinition(def), "")
+	default:
+		// Unions and scalars print on a single line after their description
+		a.member(printDefinition(def), definitionName(def))
+	}
+}
+
+// description adds the lines of a description, if there is one
+func (a *coverageAnnotator) description(desc *ast.StringValue, indent string) {
+	if printed := printDescription(desc, indent); printed != "" {
+		a.add(strings.TrimSuffix(printed, "\n"), "")
+	}
+}
+
+// header adds the line opening a type definition
+func (a *coverageAnnotator) header(text, typeName string, block bool) {
+	if block {
+		text += " {"
+	}
+	a.add(text, typeName)
+}
+
+// footer closes a type definition's block
+func (a *coverageAnnotator) footer(block bool) {
+	if block {
+		a.add("}", "")
+	}
+}
+
+// fields adds the fields of an object or interface type and closes its block
+func (a *coverageAnnotator) fields(typeName string, fields []*ast.FieldDefinition) {
+	for _, field := range fields {
+		coordinate := typeName + "." + field.Name.Value
+		a.description(field.Description, "  ")
+
+		printed := "  " + field.Name.Value + printArguments(field.Arguments) + ": " + printNode(field.Type) + printDirectives(field.Directives)
+		if !strings.Contains(printed, "\n") {
+			a.add(printed, coordinate)
+			continue
+		}
+
+		// Documented arguments print one per line, each annotated on its own
+		a.add("  "+field.Name.Value+"(", coordinate)
+		for _, arg := range field.Arguments {
+			a.member(printInputValue(arg, "    "), coordinate+"("+arg.Name.Value+":)")
+		}
+		a.add("  ): "+printNode(field.Type)+printDirectives(field.Directives), "")
+	}
+	a.footer(len(fields) > 0)
+}
+
+// member adds a printed member, annotating its last line, which follows any description
+func (a *coverageAnnotator) member(printed, coordinate string) {
+	lines := strings.Split(printed, "\n")
+	for _, line := range lines[:len(lines)-1] {
+		a.add(line, "")
+	}
+	a.add(lines[len(lines)-1], coordinate)
+}
+
+// FormatCoverageSDL prints a schema's SDL with a trailing comment on each field,
+// argument, enum value and input field, e.g. `email: String  # hits: 12, docs: 3`.
+// Hits count requests when coverage is weighted by traffic, and usages in
+// documents otherwise; arguments always count usages. Arguments printed on their
+// field's line are counted in its comment, e.g. `args: first 2 hits, after UNCOVERED`.
+// Uncovered elements are marked as such.
+func FormatCoverageSDL(schema *Schema, result *CoverageResult) (string, error) {
+	lines, err := AnnotateSchemaCoverage(schema, result)
+	if err != nil {
+		return "", err
+	}
+
+	comments := make([]string, len(lines))
+	for i, line := range lines {
+		comments[i] = coverageComment(line, result)
+	}
+
+	// Align the comments of each definition, separated by blank lines
+	var sb strings.Builder
+	for start := 0; start < len(lines); {
+		end := start
+		width := 0
+		for end < len(lines) && lines[end].Text != "" {
+			if comments[end] != "" && len(lines[end].Text) > width {
+				width = len(lines[end].Text)
+			}
+			end++
+		}
+		for i := start; i < end; i++ {
+			sb.WriteString(lines[i].Text)
+			if comments[i] != "" {
+				sb.WriteString(strings.Repeat(" ", width-len(lines[i].Text)+2) + comments[i])
+			}
+			sb.WriteString("\n")
+		}
+		if end < len(lines) {
+			sb.WriteString("\n")
+		}
+		start = end + 1
+	}
+	return sb.String(), nil
+}
+
+// coverageComment returns the trailing comment for an annotated SDL line, if any
+func coverageComment(line CoverageLine, result *CoverageResult) string {
+	if !line.Tracked {
+		return ""
+	}
+	if !line.Covered {
+		return "# UNCOVERED"
+	}
+	if !strings.Contains(line.Coordinate, ".") {
+		// Covered types are summarized by their members
+		return ""
+	}
+
+	usages := result.Usages[line.Coordinate]
+	docs := make(map[string]bool)
+	for _, usage := range usages {
+		docs[usage.Source] = true
+	}
+	hits := int64(len(usages))
+	if line.Hits != nil {
+		hits = *line.Hits
+	}
+	comment := fmt.Sprintf("# hits: %d, docs: %d", hits, len(docs))
+
+	// Arguments printed inline with their field share its comment
+	arguments := inlineArguments(line, result)
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		if i == 0 {
+			comment += ", args: "
+		} else {
+			comment += ", "
+		}
+		comment += name + " " + argumentHits(line.Coordinate, name, arguments[name], result)
+	}
+	return comment
+}
+
+// argumentHits describes the usages of an argument printed inline with its field,
+// e.g. "2 hits" or "UNCOVERED"
+func argumentHits(fieldCoordinate, name string, covered bool, result *CoverageResult) string {
+	if !covered {
+		return "UNCOVERED"
+	}
+	hits := len(result.Usages[fieldCoordinate+"("+name+":)"])
+	if hits == 1 {
+		return "1 hit"
+	}
+	return fmt.Sprintf("%d hits", hits)
+}
+
+// inlineArguments returns the coverage of the arguments printed on the same line
+// as their field, keyed by argument name
+func inlineArguments(line CoverageLine, result *CoverageResult) map[string]bool {
+	typeName, fieldName, isMember := strings.Cut(line.Coordinate, ".")
+	text := strings.TrimSpace(line.Text)
+	if !isMember || strings.Contains(fieldName, "(") || !strings.Contains(text, "(") || strings.HasSuffix(text, "(") {
+		return nil
+	}
+
+	arguments := make(map[string]bool)
+	prefix := fieldName + "("
+	for key, covered := range result.Details[typeName].Arguments {
+		if strings.HasPrefix(key, prefix) {
+			arguments[strings.TrimSuffix(strings.TrimPrefix(key, prefix), ":)")] = covered
+		}
+	}
+	return arguments
+}
@@ -0,0 +1,60 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestFormatCoverageSDLArguments(t *testing.T) {
+	schema := mustLoadSchema(t, `
+type Query {
+  users(first: Int, after: String): [User]
+  search(
+    "Text to search for"
+    text: String
+    limit: Int
+  ): [User]
+}
+type User { id: ID }
+`)
+	documents := mustLoadDocuments(t,
+		`query A { users(first: 10) { id } }`,
+		`query B { users(first: 5) { id } search(text: "x") { id } }`,
+	)
+
+	result, err := core.AnalyzeCoverage(schema, documents, nil)
+	if err != nil {
+		t.Fatalf("AnalyzeCoverage() error = %v", err)
+	}
+	sdl, err := core.FormatCoverageSDL(schema, result)
+	if err != nil {
+		t.Fatalf("FormatCoverageSDL() error = %v", err)
+	}
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "users(first: Int, after: String): [User]", want: "# hits: 2, docs: 2, args: after UNCOVERED, first 2 hits"},
+		{line: "search(", want: "# hits: 1, docs: 1"},
+		{line: "text: String", want: "# hits: 1, docs: 1"},
+		{line: "limit: Int", want: "# UNCOVERED"},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, line := range strings.Split(sdl, "\n") {
+			code, comment, _ := strings.Cut(line, "#")
+			if strings.TrimSpace(code) == tt.line {
+				found = true
+				if got := "#" + comment; got != tt.want {
+					t.Errorf("comment on %q = %q, want %q", tt.line, got, tt.want)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("line %q not found in:\n%s", tt.line, sdl)
+		}
+	}
+}
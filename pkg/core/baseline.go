@@ -0,0 +1,107 @@
+package core
+
+import "sort"
+
+// CoverageComparison compares coverage against a baseline from an earlier run
+type CoverageComparison struct {
+	BaselineCoverage float64 `json:"baselineCoverage"`
+	Coverage         float64 `json:"coverage"`
+	Tolerance        float64 `json:"tolerance"`
+	// NewlyCovered and NewlyUncovered list the schema coordinates whose coverage
+	// changed, such as User.email or Query.users(first:)
+	NewlyCovered   []string             `json:"newlyCovered,omitempty"`
+	NewlyUncovered []string             `json:"newlyUncovered,omitempty"`
+	TypeDecreases  []TypeCoverageChange `json:"typeDecreases,omitempty"`
+	// Regressed is set when overall or per-type coverage dropped by more than the tolerance
+	Regressed bool `json:"regressed"`
+}
+
+// TypeCoverageChange records a type whose coverage dropped by more than the tolerance
+type TypeCoverageChange struct {
+	Type     string  `json:"type"`
+	Baseline float64 `json:"baseline"`
+	Coverage float64 `json:"coverage"`
+}
+
+// CompareCoverage compares coverage against a baseline. Coverage regresses when the
+// overall ratio, or the ratio of any type present in both runs, drops by more than
+// tolerance. Coordinates missing from either run, e.g. after a schema change, are
+// neither newly covered nor newly uncovered unless they are covered now.
+func CompareCoverage(baseline, current *CoverageResult, tolerance float64) *CoverageComparison {
+	comparison := &CoverageComparison{
+		BaselineCoverage: baseline.Coverage,
+		Coverage:         current.Coverage,
+		Tolerance:        tolerance,
+	}
+
+	before := coverageCoordinates(baseline)
+	after := coverageCoordinates(current)
+	for coordinate, covered := range after {
+		wasCovered, existed := before[coordinate]
+		switch {
+		case covered && !wasCovered:
+			comparison.NewlyCovered = append(comparison.NewlyCovered, coordinate)
+		case !covered && existed && wasCovered:
+			comparison.NewlyUncovered = append(comparison.NewlyUncovered, coordinate)
+		}
+	}
+	sort.Strings(comparison.NewlyCovered)
+	sort.Strings(comparison.NewlyUncovered)
+
+	for typeName, typeCoverage := range current.Details {
+		baselineType, exists := baseline.Details[typeName]
+		if !exists {
+			continue
+		}
+		ratio, ok := typeCoverageRatio(typeCoverage)
+		baselineRatio, baselineOk := typeCoverageRatio(baselineType)
+		if ok && baselineOk && ratio < baselineRatio-tolerance {
+			comparison.TypeDecreases = append(comparison.TypeDecreases, TypeCoverageChange{
+				Type:     typeName,
+				Baseline: baselineRatio,
+				Coverage: ratio,
+			})
+		}
+	}
+	sort.Slice(comparison.TypeDecreases, func(i, j int) bool {
+		return comparison.TypeDecreases[i].Type < comparison.TypeDecreases[j].Type
+	})
+
+	comparison.Regressed = current.Coverage < baseline.Coverage-tolerance || len(comparison.TypeDecreases) > 0
+	return comparison
+}
+
+// coverageCoordinates returns whether each type and member of a coverage result
+// is covered, keyed by schema coordinate
+func coverageCoordinates(result *CoverageResult) map[string]bool {
+	coordinates := make(map[string]bool)
+	for typeName, typeCoverage := range result.Details {
+		coordinates[typeName] = typeCoverage.Covered
+		for _, members := range []map[string]bool{typeCoverage.Fields, typeCoverage.Arguments, typeCoverage.EnumValues, typeCoverage.InputFields} {
+			for name, covered := range members {
+				coordinates[typeName+"."+name] = covered
+			}
+		}
+	}
+	return coordinates
+}
+
+// typeCoverageRatio returns the share of a type's members that are covered, or
+// false for types without members such as scalars
+func typeCoverageRatio(typeCoverage TypeCoverage) (float64, bool) {
+	covered, total := typeMemberCounts(typeCoverage)
+	if total == 0 {
+		return 0, false
+	}
+	return coverageRatio(covered, total), true
+}
+
+// typeMemberCounts counts the covered and total fields, arguments, enum values and
+// input fields of a type
+func typeMemberCounts(typeCoverage TypeCoverage) (int, int) {
+	covered, total := 0, 0
+	for _, members := range []map[string]bool{typeCoverage.Fields, typeCoverage.Arguments, typeCoverage.EnumValues, typeCoverage.InputFields} {
+		covered, total = countCovered(members, covered, total)
+	}
+	return covered, total
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// parseCoordinate splits a change path such as "User.posts(first:)" into its
+// type, field and argument names
+func parseCoordinate(path string) (typeName, fieldName, argName string) {
+	typeName = path
+	if i := strings.Index(path, "."); i >= 0 {
+		typeName = path[:i]
+		fieldName = path[i+1:]
+	}
+	if i := strings.Index(fieldName, "("); i >= 0 {
+		argName = strings.TrimSuffix(fieldName[i+1:], ":)")
+		fieldName = fieldName[:i]
+	}
+	return typeName, fieldName, argName
+}
+
+// parentCoordinate returns the enclosing coordinate of a path, or "" for a type
+func parentCoordinate(path string) string {
+	typeName, fieldName, argName := parseCoordinate(path)
+	switch {
+	case argName != "":
+		return typeName + "." + fieldName
+	case fieldName != "":
+		return typeName
+	default:
+		return ""
+	}
+}
+
+// isCoordinateAncestor reports whether ancestor encloses path
+func isCoordinateAncestor(ancestor, path string) bool {
+	for parent := parentCoordinate(path); parent != ""; parent = parentCoordinate(parent) {
+		if parent == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCoordinate reports whether a schema defines the type, field or argument a path points to
+func hasCoordinate(schema *graphql.Schema, path string) bool {
+	typeName, fieldName, argName := parseCoordinate(path)
+
+	t := schema.Type(typeName)
+	if t == nil {
+		return false
+	}
+	if fieldName == "" {
+		return true
+	}
+
+	switch t := t.(type) {
+	case *graphql.Object:
+		return hasFieldArgument(t.Fields()[fieldName], argName)
+	case *graphql.Interface:
+		return hasFieldArgument(t.Fields()[fieldName], argName)
+	case *graphql.InputObject:
+		_, exists := t.Fields()[fieldName]
+		return exists && argName == ""
+	case *graphql.Enum:
+		for _, value := range t.Values() {
+			if value.Name == fieldName {
+				return argName == ""
+			}
+		}
+	}
+	return false
+}
+
+// hasFieldArgument reports whether a field exists and, if given, has the named argument
+func hasFieldArgument(field *graphql.FieldDefinition, argName string) bool {
+	if field == nil {
+		return false
+	}
+	if argName == "" {
+		return true
+	}
+	for _, arg := range field.Args {
+		if arg.Name() == argName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,401 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// CostOptions represents options for operation cost analysis
+type CostOptions struct {
+	// TypeCosts overrides the default cost of selecting a type, which is 1 for
+	// objects, interfaces and unions and 0 for scalars and enums
+	TypeCosts map[string]int `json:"typeCosts,omitempty"`
+	// DefaultListSize is the size assumed for lists that have neither a slicing
+	// argument nor an @listSize(assumedSize:)
+	DefaultListSize int `json:"defaultListSize"`
+}
+
+// CostNode represents the cost of a field selection and the selections below it
+type CostNode struct {
+	Path       string     `json:"path"`
+	Coordinate string     `json:"coordinate"`
+	Weight     int        `json:"weight"`
+	Multiplier int        `json:"multiplier"`
+	Cost       int        `json:"cost"`
+	Children   []CostNode `json:"children,omitempty"`
+}
+
+// defaultSlicingArguments are the arguments that size a list when the field has no @listSize
+var defaultSlicingArguments = []string{"first", "last"}
+
+// costAnalyzer computes operation costs following the GraphQL cost directive
+// specification: a field costs its weight plus that of its selections, times the
+// size of the list it returns, plus the weights of the arguments it is given.
+// Fragments on different types are all counted, so costs on abstract types are
+// an upper bound.
+type costAnalyzer struct {
+	schema     *graphql.Schema
+	document   *ast.Document
+	directives map[string][]*ast.Directive
+	fragments  map[string]*ast.FragmentDefinition
+	// provided holds the variables given with the document; variables adds the
+	// defaults of the operation being analyzed
+	provided  map[string]interface{}
+	variables map[string]interface{}
+	options   *CostOptions
+	spreading map[string]bool
+}
+
+// newCostAnalyzer creates a cost analyzer for a document. Weights and list sizes
+// are read from @cost and @listSize directives in the schema SDL.
+func newCostAnalyzer(schema *Schema, docAST *ast.Document, variables map[string]interface{}, options *CostOptions) *costAnalyzer {
+	if options == nil {
+		options = &CostOptions{}
+	}
+
+	directives := make(map[string][]*ast.Directive)
+	if sdl, err := parseSchemaSDL(schema); err == nil {
+		directives = directivesByCoordinate(sdl)
+	}
+
+	return &costAnalyzer{
+		schema:     schema.Schema,
+		document:   docAST,
+		directives: directives,
+		fragments:  fragmentDefinitions(docAST),
+		provided:   variables,
+		options:    options,
+		spreading:  make(map[string]bool),
+	}
+}
+
+// operationCost returns the total cost of an operation and its per-field breakdown
+func (a *costAnalyzer) operationCost(opDef *ast.OperationDefinition) (int, []CostNode) {
+	root := rootType(a.schema, opDef)
+	if root == nil {
+		return 0, nil
+	}
+
+	// Fall back to the default values of variables the caller didn't provide
+	provided, _ := operationVariables(a.provided, a.document, opDef)
+	a.variables = make(map[string]interface{}, len(provided))
+	for name, value := range provided {
+		a.variables[name] = value
+	}
+	for _, def := range opDef.VariableDefinitions {
+		name := def.Variable.Name.Value
+		if _, exists := a.variables[name]; !exists && def.DefaultValue != nil {
+			a.variables[name] = def.DefaultValue.GetValue()
+		}
+	}
+
+	nodes, cost := a.selectionSetCost(root, opDef.SelectionSet, "", nil)
+	return cost, nodes
+}
+
+// selectionSetCost returns the cost of the selections made on a type. sized maps
+// the names of fields that a parent's @listSize(sizedFields:) sizes to their list size.
+func (a *costAnalyzer) selectionSetCost(parent graphql.Type, selectionSet *ast.SelectionSet, path string, sized map[string]int) ([]CostNode, int) {
+	if selectionSet == nil {
+		return nil, 0
+	}
+
+	var nodes []CostNode
+	total := 0
+
+	for _, selection := range selectionSet.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			fieldDef := fieldDefinition(parent, sel.Name.Value)
+			if fieldDef == nil {
+				continue // Introspection fields and fields the schema doesn't define are free
+			}
+			node := a.fieldCost(parent.Name(), fieldDef, sel, path, sized)
+			nodes = append(nodes, node)
+			total += node.Cost
+		case *ast.InlineFragment:
+			fragmentType := parent
+			if sel.TypeCondition != nil {
+				if t := a.schema.Type(sel.TypeCondition.Name.Value); t != nil {
+					fragmentType = t
+				}
+			}
+			children, cost := a.selectionSetCost(fragmentType, sel.SelectionSet, path, sized)
+			nodes = append(nodes, children...)
+			total += cost
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			fragment, exists := a.fragments[name]
+			if !exists || a.spreading[name] {
+				continue
+			}
+			fragmentType := parent
+			if fragment.TypeCondition != nil {
+				if t := a.schema.Type(fragment.TypeCondition.Name.Value); t != nil {
+					fragmentType = t
+				}
+			}
+			a.spreading[name] = true
+			children, cost := a.selectionSetCost(fragmentType, fragment.SelectionSet, path, sized)
+			delete(a.spreading, name)
+			nodes = append(nodes, children...)
+			total += cost
+		}
+	}
+
+	return nodes, total
+}
+
+// fieldCost computes the cost of a single field selection
+func (a *costAnalyzer) fieldCost(typeName string, fieldDef *graphql.FieldDefinition, field *ast.Field, path string, sized map[string]int) CostNode {
+	coordinate := typeName + "." + fieldDef.Name
+	fieldPath := responseName(field)
+	if path != "" {
+		fieldPath = path + "." + fieldPath
+	}
+
+	weight := a.typeWeight(fieldDef.Type)
+	if cost, ok := a.costWeight(coordinate); ok {
+		weight = cost
+	}
+
+	argumentWeight := 0
+	for _, arg := range field.Arguments {
+		if cost, ok := a.costWeight(coordinate + "(" + arg.Name.Value + ":)"); ok {
+			argumentWeight += cost
+		}
+	}
+
+	multiplier := 1
+	var childSized map[string]int
+	if size, ok := sized[fieldDef.Name]; ok {
+		multiplier = size
+	} else if listSize, sizedFields, ok := a.listSize(coordinate, fieldDef, field); ok {
+		if len(sizedFields) > 0 {
+			// The size applies to the list fields of the returned type, e.g. a connection's edges
+			childSized = make(map[string]int, len(sizedFields))
+			for _, name := range sizedFields {
+				childSized[name] = listSize
+			}
+		} else {
+			multiplier = listSize
+		}
+	}
+
+	children, childCost := a.selectionSetCost(unwrapType(fieldDef.Type), field.SelectionSet, fieldPath, childSized)
+
+	return CostNode{
+		Path:       fieldPath,
+		Coordinate: coordinate,
+		Weight:     weight + argumentWeight,
+		Multiplier: multiplier,
+		Cost:       multiplier*(weight+childCost) + argumentWeight,
+		Children:   children,
+	}
+}
+
+// costSuggestion points at the selection that accounts for most of an operation's
+// cost, descending while a single child makes up more than half of its parent
+func costSuggestion(nodes []CostNode) string {
+	var hotspot *CostNode
+	for len(nodes) > 0 {
+		top := &nodes[0]
+		for i := range nodes {
+			if nodes[i].Cost > top.Cost {
+				top = &nodes[i]
+			}
+		}
+		if hotspot != nil && top.Cost*2 <= hotspot.Cost {
+			break
+		}
+		hotspot = top
+		nodes = top.Children
+	}
+	if hotspot == nil || hotspot.Cost == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Most of the cost comes from '%s' (%d); request smaller pages or fewer fields there", hotspot.Path, hotspot.Cost)
+}
+
+// typeWeight returns the cost of selecting a value of a type
+func (a *costAnalyzer) typeWeight(t graphql.Type) int {
+	named := unwrapType(t)
+	if cost, ok := a.costWeight(named.Name()); ok {
+		return cost
+	}
+	if cost, ok := a.options.TypeCosts[named.Name()]; ok {
+		return cost
+	}
+	switch named.(type) {
+	case *graphql.Scalar, *graphql.Enum:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// costWeight returns the weight of an @cost directive applied to a coordinate
+func (a *costAnalyzer) costWeight(coordinate string) (int, bool) {
+	directive := findDirective(a.directives[coordinate], "cost")
+	if directive == nil {
+		return 0, false
+	}
+	value := directiveArgument(directive, "weight")
+	if value == nil {
+		return 0, false
+	}
+
+	var raw string
+	switch value := value.(type) {
+	case *ast.StringValue:
+		raw = value.Value
+	case *ast.IntValue:
+		raw = value.Value
+	case *ast.FloatValue:
+		raw = value.Value
+	default:
+		return 0, false
+	}
+	weight, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(math.Ceil(weight)), true
+}
+
+// listSize returns the expected size of the list a field returns, along with the
+// fields of the returned type the size applies to. Slicing arguments take
+// precedence over @listSize(assumedSize:), which takes precedence over the default.
+func (a *costAnalyzer) listSize(coordinate string, fieldDef *graphql.FieldDefinition, field *ast.Field) (int, []string, bool) {
+	directive := findDirective(a.directives[coordinate], "listSize")
+	if directive == nil && !isListType(fieldDef.Type) {
+		return 0, nil, false
+	}
+
+	size := a.options.DefaultListSize
+	if size <= 0 {
+		size = 1
+	}
+	slicingArguments := defaultSlicingArguments
+	var sizedFields []string
+
+	if directive != nil {
+		if value, ok := directiveArgument(directive, "assumedSize").(*ast.IntValue); ok {
+			if assumed, err := strconv.Atoi(value.Value); err == nil {
+				size = assumed
+			}
+		}
+		if value := directiveArgument(directive, "slicingArguments"); value != nil {
+			slicingArguments = stringListValue(value)
+		}
+		if value := directiveArgument(directive, "sizedFields"); value != nil {
+			sizedFields = stringListValue(value)
+		}
+	}
+
+	sliced := -1
+	for _, arg := range field.Arguments {
+		if !containsString(slicingArguments, arg.Name.Value) {
+			continue
+		}
+		if value, ok := a.intArgument(arg.Value); ok && value > sliced {
+			sliced = value
+		}
+	}
+	if sliced >= 0 {
+		size = sliced
+	}
+
+	return size, sizedFields, true
+}
+
+// intArgument resolves an integer argument given as a literal or a variable
+func (a *costAnalyzer) intArgument(value ast.Value) (int, bool) {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		n, err := strconv.Atoi(value.Value)
+		return n, err == nil
+	case *ast.Variable:
+		variable := a.variables[value.Name.Value]
+		if s, ok := variable.(string); ok {
+			// Default values are read from the document as strings
+			n, err := strconv.Atoi(s)
+			return n, err == nil
+		}
+		if n, ok := numberValue(variable); ok {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// fieldDefinition looks up a field on an object or interface type
+func fieldDefinition(parent graphql.Type, name string) *graphql.FieldDefinition {
+	switch parent := parent.(type) {
+	case *graphql.Object:
+		return parent.Fields()[name]
+	case *graphql.Interface:
+		return parent.Fields()[name]
+	}
+	return nil
+}
+
+// isListType reports whether a type is a list, possibly wrapped in non-null
+func isListType(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
+
+// findDirective returns the first directive with the given name
+func findDirective(directives []*ast.Directive, name string) *ast.Directive {
+	for _, directive := range directives {
+		if directive.Name != nil && directive.Name.Value == name {
+			return directive
+		}
+	}
+	return nil
+}
+
+// directiveArgument returns the value of a directive argument, or nil when it isn't given
+func directiveArgument(directive *ast.Directive, name string) ast.Value {
+	for _, arg := range directive.Arguments {
+		if arg.Name.Value == name {
+			return arg.Value
+		}
+	}
+	return nil
+}
+
+// stringListValue reads a list of strings, also accepting a single string
+func stringListValue(value ast.Value) []string {
+	switch value := value.(type) {
+	case *ast.StringValue:
+		return []string{value.Value}
+	case *ast.ListValue:
+		values := make([]string, 0, len(value.Values))
+		for _, item := range value.Values {
+			if s, ok := item.(*ast.StringValue); ok {
+				values = append(values, s.Value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// containsString reports whether a string is in a list
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
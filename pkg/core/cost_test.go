@@ -0,0 +1,164 @@
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestValidateOperationComplexity(t *testing.T) {
+	schema := mustLoadSchema(t, `
+directive @cost(weight: Int!) on FIELD_DEFINITION | ARGUMENT_DEFINITION | OBJECT
+directive @listSize(assumedSize: Int, slicingArguments: [String!], sizedFields: [String!]) on FIELD_DEFINITION
+
+type Query {
+  users(first: Int, last: Int): [User]
+  featured: [User] @listSize(assumedSize: 5)
+  search(limit: Int): [User] @listSize(slicingArguments: ["limit"], assumedSize: 50)
+  usersConnection(first: Int): UserConnection @listSize(slicingArguments: ["first"], sizedFields: ["edges"])
+  expensive(filter: String @cost(weight: 3)): User @cost(weight: 10)
+}
+type User { id: ID name: String friends(first: Int): [User] }
+type UserConnection { edges: [UserEdge] }
+type UserEdge { node: User }
+`)
+
+	tests := []struct {
+		name      string
+		document  string
+		variables map[string]interface{}
+		options   *core.CostOptions
+		want      int
+	}{
+		{
+			name:     "slicing argument sizes the list",
+			document: `{ users(first: 3) { id } }`,
+			want:     3,
+		},
+		{
+			name:     "the largest slicing argument wins",
+			document: `{ users(first: 3, last: 4) { id } }`,
+			want:     4,
+		},
+		{
+			name:     "lists without a size use DefaultListSize",
+			document: `{ users { id } }`,
+			options:  &core.CostOptions{DefaultListSize: 10},
+			want:     10,
+		},
+		{
+			name:     "lists without any size count once",
+			document: `{ users { id } }`,
+			want:     1,
+		},
+		{
+			name:     "assumedSize takes precedence over DefaultListSize",
+			document: `{ featured { id } }`,
+			options:  &core.CostOptions{DefaultListSize: 10},
+			want:     5,
+		},
+		{
+			name:     "custom slicing argument takes precedence over assumedSize",
+			document: `{ search(limit: 4) { id } }`,
+			want:     4,
+		},
+		{
+			name:     "assumedSize applies when the slicing argument is missing",
+			document: `{ search { id } }`,
+			want:     50,
+		},
+		{
+			name:     "sizedFields size the connection's edges instead of the connection",
+			document: `{ usersConnection(first: 7) { edges { node { id } } } }`,
+			// usersConnection 1 + edges 7 × (1 + node 1)
+			want: 15,
+		},
+		{
+			name:     "field and argument weights",
+			document: `{ expensive(filter: "x") { id } }`,
+			want:     13,
+		},
+		{
+			name:     "argument weights only count when the argument is given",
+			document: `{ expensive { id } }`,
+			want:     10,
+		},
+		{
+			name:     "nested lists multiply",
+			document: `{ users(first: 2) { friends(first: 3) { name } } }`,
+			// users 2 × (1 + friends 3 × 1)
+			want: 8,
+		},
+		{
+			name:     "type costs override the default weight",
+			document: `{ users(first: 3) { id } }`,
+			options:  &core.CostOptions{TypeCosts: map[string]int{"User": 2}},
+			want:     6,
+		},
+		{
+			name:      "variable-sized first",
+			document:  `query Users($n: Int) { users(first: $n) { id } }`,
+			variables: map[string]interface{}{"n": float64(4)},
+			want:      4,
+		},
+		{
+			name:      "variable-sized first decoded as json.Number",
+			document:  `query Users($n: Int) { users(first: $n) { id } }`,
+			variables: map[string]interface{}{"n": json.Number("9")},
+			want:      9,
+		},
+		{
+			name:     "variable-sized first falls back to its default",
+			document: `query Users($n: Int = 6) { users(first: $n) { id } }`,
+			want:     6,
+		},
+		{
+			name:     "variable-sized first without a value uses the list size",
+			document: `query Users($n: Int) { users(first: $n) { id } }`,
+			options:  &core.CostOptions{DefaultListSize: 20},
+			want:     20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents := mustLoadDocuments(t, tt.document)
+			documents[0].Variables = tt.variables
+			results, err := core.ValidateOperationComplexity(schema, documents, 1000, tt.options)
+			if err != nil {
+				t.Fatalf("ValidateOperationComplexity() error = %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("got %d results, want 1", len(results))
+			}
+			if results[0].Complexity != tt.want {
+				t.Errorf("Complexity = %d, want %d", results[0].Complexity, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOperationComplexityReportsHotspot(t *testing.T) {
+	schema := mustLoadSchema(t, `
+type Query { users(first: Int): [User] me: User }
+type User { id: ID friends(first: Int): [User] }
+`)
+	documents := mustLoadDocuments(t, `query Feed { me { id } users(first: 10) { friends(first: 10) { id } } }`)
+
+	results, err := core.ValidateOperationComplexity(schema, documents, 50, nil)
+	if err != nil {
+		t.Fatalf("ValidateOperationComplexity() error = %v", err)
+	}
+	if len(results) != 1 || results[0].IsValid || results[0].Diagnostic == nil {
+		t.Fatalf("results = %+v, want one operation over the limit", results)
+	}
+
+	diagnostic := results[0].Diagnostic
+	if want := "Operation 'Feed' has complexity 111, exceeding maximum of 50"; diagnostic.Message != want {
+		t.Errorf("Message = %q, want %q", diagnostic.Message, want)
+	}
+	if want := "Most of the cost comes from 'users' (110); request smaller pages or fewer fields there"; diagnostic.Suggestion != want {
+		t.Errorf("Suggestion = %q, want %q", diagnostic.Suggestion, want)
+	}
+}
@@ -2,12 +2,12 @@ package core
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
-	"github.com/graphql-go/graphql/language/parser"
-	"github.com/graphql-go/graphql/language/visitor"
+	"github.com/graphql-go/graphql/language/source"
 )
 
 // AnalyzeCoverage analyzes schema coverage based on GraphQL documents
@@ -18,29 +18,112 @@ func AnalyzeCoverage(schema *Schema, documents []Document, options *CoverageOpti
 
 	if options == nil {
 		options = &CoverageOptions{
-			Schema:    schema,
-			Documents: documents,
-			Threshold: 0.8,
+			Schema:             schema,
+			Documents:          documents,
+			Threshold:          0.8,
+			IncludeArguments:   true,
+			IncludeEnumValues:  true,
+			IncludeInputFields: true,
 		}
 	}
 
 	// Initialize coverage tracking
-	coverage := initializeCoverage(schema.Schema)
-
-	// Analyze each document
-	for _, doc := range documents {
-		if err := analyzeDocument(schema, doc, coverage); err != nil {
-			continue // Skip invalid documents
-		}
+	coverage := initializeCoverage(schema.Schema, options)
+	usages := newUsageRecorder()
+	abstracts := newAbstractRecorder(schema.Schema)
+
+	// Traffic weighs each operation's contributions by the requests it served;
+	// without it, each usage counts once
+	var traffic *trafficWeights
+	weight := int64(1)
+	if options.Traffic != nil {
+		traffic = newTrafficWeights(options.Traffic, options.TrafficSince)
 	}
 
+	// Analyze each document, resolving fragments across the whole set
+	walkCoverage(schema, documents, &coverageVisitor{
+		operation: func(opDef *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition) {
+			if traffic != nil {
+				weight = traffic.weight(opDef, fragments)
+			}
+		},
+		field: func(parent graphql.Type, fieldDef *graphql.FieldDefinition, usage CoordinateUsage) {
+			trackFieldUsage(parent, fieldDef, coverage, weight)
+			if typeCoverage, exists := coverage[parent.Name()]; exists && typeCoverage.FieldHits != nil {
+				typeCoverage.Hits += weight
+				typeCoverage.FieldHits[fieldDef.Name] += weight
+			}
+			usages.record(parent.Name()+"."+fieldDef.Name, usage)
+		},
+		argument: func(parent graphql.Type, fieldDef *graphql.FieldDefinition, arg *graphql.Argument, usage CoordinateUsage) {
+			if typeCoverage, exists := coverage[parent.Name()]; exists && typeCoverage.Arguments != nil {
+				key := argumentKey(fieldDef.Name, arg.Name())
+				typeCoverage.Arguments[key] = true
+				usages.record(parent.Name()+"."+key, usage)
+			}
+		},
+		inputField: func(inputObject *graphql.InputObject, name string, usage CoordinateUsage) {
+			if typeCoverage, exists := coverage[inputObject.Name()]; exists && typeCoverage.InputFields != nil {
+				if _, defined := typeCoverage.InputFields[name]; defined {
+					typeCoverage.Covered = true
+					typeCoverage.InputFields[name] = true
+					typeCoverage.UsageCount += int(weight)
+					usages.record(inputObject.Name()+"."+name, usage)
+				}
+			}
+		},
+		abstractField: abstracts.record,
+		enumValue: func(enum *graphql.Enum, value string, usage CoordinateUsage) {
+			if typeCoverage, exists := coverage[enum.Name()]; exists && typeCoverage.EnumValues != nil {
+				if _, defined := typeCoverage.EnumValues[value]; defined {
+					typeCoverage.Covered = true
+					typeCoverage.EnumValues[value] = true
+					typeCoverage.UsageCount += int(weight)
+					usages.record(enum.Name()+"."+value, usage)
+				}
+			}
+		},
+	})
+
 	// Calculate coverage statistics
 	result := calculateCoverageStats(coverage)
+	result.Usages = usages.usages
+	result.AbstractTypes = abstracts.results()
+	if traffic != nil {
+		result.ZeroTraffic = zeroTrafficFields(coverage)
+	}
 	return result, nil
 }
 
+// usageRecorder collects the usages of each coordinate, once per operation and location
+type usageRecorder struct {
+	usages map[string][]CoordinateUsage
+	seen   map[string]bool
+}
+
+// newUsageRecorder creates an empty usage recorder
+func newUsageRecorder() *usageRecorder {
+	return &usageRecorder{
+		usages: make(map[string][]CoordinateUsage),
+		seen:   make(map[string]bool),
+	}
+}
+
+// record adds a usage of a coordinate, unless the operation already uses it at the same location
+func (r *usageRecorder) record(coordinate string, usage CoordinateUsage) {
+	key := fmt.Sprintf("%s|%s|%s", coordinate, usage.Source, usage.Operation)
+	if usage.Range != nil {
+		key += fmt.Sprintf("|%d:%d", usage.Range.Start.Line, usage.Range.Start.Column)
+	}
+	if r.seen[key] {
+		return
+	}
+	r.seen[key] = true
+	r.usages[coordinate] = append(r.usages[coordinate], usage)
+}
+
 // initializeCoverage initializes the coverage tracking structure
-func initializeCoverage(schema *graphql.Schema) map[string]*TypeCoverage {
+func initializeCoverage(schema *graphql.Schema, options *CoverageOptions) map[string]*TypeCoverage {
 	coverage := make(map[string]*TypeCoverage)
 
 	// Initialize coverage for all types
@@ -57,15 +140,26 @@ func initializeCoverage(schema *graphql.Schema) map[string]*TypeCoverage {
 			UsageCount: 0,
 		}
 
-		// Initialize fields for object and interface types
+		// Initialize fields for object and interface types, and the members of
+		// the other dimensions that are included
 		switch t := graphqlType.(type) {
 		case *graphql.Object:
-			for fieldName := range t.Fields() {
-				typeCoverage.Fields[fieldName] = false
-			}
+			initializeFieldCoverage(typeCoverage, t.Fields(), options)
 		case *graphql.Interface:
-			for fieldName := range t.Fields() {
-				typeCoverage.Fields[fieldName] = false
+			initializeFieldCoverage(typeCoverage, t.Fields(), options)
+		case *graphql.Enum:
+			if options.IncludeEnumValues {
+				typeCoverage.EnumValues = make(map[string]bool)
+				for _, value := range t.Values() {
+					typeCoverage.EnumValues[value.Name] = false
+				}
+			}
+		case *graphql.InputObject:
+			if options.IncludeInputFields {
+				typeCoverage.InputFields = make(map[string]bool)
+				for fieldName := range t.Fields() {
+					typeCoverage.InputFields[fieldName] = false
+				}
 			}
 		}
 
@@ -75,91 +169,431 @@ func initializeCoverage(schema *graphql.Schema) map[string]*TypeCoverage {
 	return coverage
 }
 
-// analyzeDocument analyzes a single document for coverage
-func analyzeDocument(schema *Schema, doc Document, coverage map[string]*TypeCoverage) error {
-	// Parse the document if AST is not provided
-	var docAST *ast.Document
-	if doc.AST != nil {
-		docAST = doc.AST
-	} else {
-		parsed, err := parser.Parse(parser.ParseParams{
-			Source: doc.Content,
-		})
-		if err != nil {
-			return err
+// initializeFieldCoverage initializes the fields of a type, and their arguments when included
+func initializeFieldCoverage(typeCoverage *TypeCoverage, fields graphql.FieldDefinitionMap, options *CoverageOptions) {
+	if options.IncludeArguments {
+		typeCoverage.Arguments = make(map[string]bool)
+	}
+	if options.Traffic != nil {
+		typeCoverage.FieldHits = make(map[string]int64)
+	}
+	for fieldName, fieldDef := range fields {
+		typeCoverage.Fields[fieldName] = false
+		if options.Traffic != nil {
+			typeCoverage.FieldHits[fieldName] = 0
+		}
+		if options.IncludeArguments {
+			for _, arg := range fieldDef.Args {
+				typeCoverage.Arguments[argumentKey(fieldName, arg.Name())] = false
+			}
 		}
-		docAST = parsed
 	}
+}
+
+// argumentKey identifies an argument within its type, like the schema coordinate User.posts(first:)
+func argumentKey(fieldName, argName string) string {
+	return fmt.Sprintf("%s(%s:)", fieldName, argName)
+}
 
-	// Visit the document and track field usage
-	visitor.Visit(docAST, &visitor.VisitorOptions{
-		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
-			if field, ok := p.Node.(*ast.Field); ok {
-				// Track field usage
-				if err := trackFieldUsage(schema, field, coverage, nil); err != nil {
-					// Continue on error
+// coverageVisitor receives the parts of the schema an operation uses, along with
+// where the operation uses them. Callbacks may be nil.
+type coverageVisitor struct {
+	// operation is called before the selections of each operation are walked, with
+	// the fragments visible to it
+	operation  func(opDef *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition)
+	field      func(parent graphql.Type, fieldDef *graphql.FieldDefinition, usage CoordinateUsage)
+	argument   func(parent graphql.Type, fieldDef *graphql.FieldDefinition, arg *graphql.Argument, usage CoordinateUsage)
+	inputField func(inputObject *graphql.InputObject, name string, usage CoordinateUsage)
+	enumValue  func(enum *graphql.Enum, value string, usage CoordinateUsage)
+	// unknownVariable is called for variables passed without a provided or default
+	// value, with the input type they are passed for
+	unknownVariable func(t graphql.Type)
+	// abstractField is called for fields returning an interface or union, with the
+	// possible types their fragments handle
+	abstractField func(parent graphql.Type, fieldDef *graphql.FieldDefinition, abstract graphql.Abstract, handled map[string]bool, usage CoordinateUsage)
+}
+
+// coverageWalker walks the operations of a document set with the type each
+// selection applies to in scope, reporting every field selected
+type coverageWalker struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	spreading map[string]bool
+	visitor   *coverageVisitor
+	// doc holds the variables given with the document; definitions and
+	// variables are those of the operation being walked
+	doc         Document
+	document    *ast.Document
+	definitions map[string]*ast.VariableDefinition
+	variables   map[string]interface{}
+	// sources names the document each source belongs to, since fragments may
+	// come from other documents than the operation
+	sources   map[*source.Source]string
+	source    string
+	operation string
+}
+
+// walkCoverage reports the fields selected by the operations of a set of
+// documents along with their parent type, and the arguments, input fields and
+// enum values they pass. Fragments are followed from the operations spreading
+// them, narrowing to their type condition, so fragments that no operation uses
+// don't count. Variables count with the values provided for the document, or
+// their default values.
+func walkCoverage(schema *Schema, documents []Document, visitor *coverageVisitor) {
+	parsed := parseDocuments(documents)
+	fragments := newFragmentIndex(parsed)
+
+	sources := make(map[*source.Source]string)
+	for _, doc := range parsed {
+		if doc.err == nil && doc.ast.Loc != nil && doc.ast.Loc.Source != nil {
+			sources[doc.ast.Loc.Source] = doc.doc.Source
+		}
+	}
+
+	for _, doc := range parsed {
+		if doc.err != nil {
+			continue // Skip invalid documents
+		}
+		walker := &coverageWalker{
+			sources:   sources,
+			source:    doc.doc.Source,
+			schema:    schema.Schema,
+			fragments: fragments.resolver(doc.ast),
+			spreading: make(map[string]bool),
+			visitor:   visitor,
+			doc:       doc.doc,
+			document:  doc.ast,
+		}
+		for _, def := range doc.ast.Definitions {
+			if opDef, ok := def.(*ast.OperationDefinition); ok {
+				walker.walkOperation(opDef)
+			}
+		}
+	}
+}
+
+// walkOperation walks an operation from its root type
+func (w *coverageWalker) walkOperation(opDef *ast.OperationDefinition) {
+	w.definitions = make(map[string]*ast.VariableDefinition, len(opDef.VariableDefinitions))
+	for _, def := range opDef.VariableDefinitions {
+		w.definitions[def.Variable.Name.Value] = def
+	}
+	w.variables, _ = operationVariables(w.doc.Variables, w.document, opDef)
+	w.operation = getOperationName(opDef)
+	if w.visitor.operation != nil {
+		w.visitor.operation(opDef, w.fragments)
+	}
+
+	root := rootType(w.schema, opDef)
+	if root != nil {
+		w.walkSelectionSet(root, opDef.SelectionSet)
+	}
+}
+
+// usage returns where the operation being walked uses a node
+func (w *coverageWalker) usage(node ast.Node) CoordinateUsage {
+	usage := CoordinateUsage{
+		Source:    w.source,
+		Operation: w.operation,
+		Range:     nodeRange(node),
+	}
+	if loc := node.GetLoc(); loc != nil && loc.Source != nil {
+		if name, exists := w.sources[loc.Source]; exists {
+			usage.Source = name
+		}
+	}
+	return usage
+}
+
+// walkSelectionSet walks the selections made on a type
+func (w *coverageWalker) walkSelectionSet(parent graphql.Type, selectionSet *ast.SelectionSet) {
+	if selectionSet == nil {
+		return
+	}
+
+	for _, selection := range selectionSet.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			fieldDef := fieldDefinition(parent, sel.Name.Value)
+			if fieldDef == nil {
+				continue // __typename and unknown fields
+			}
+			if w.visitor.field != nil {
+				w.visitor.field(parent, fieldDef, w.usage(sel))
+			}
+			w.walkArguments(parent, fieldDef, sel.Arguments)
+			if abstract, ok := unwrapType(fieldDef.Type).(graphql.Abstract); ok && w.visitor.abstractField != nil && sel.SelectionSet != nil {
+				handled := make(map[string]bool)
+				w.collectHandledTypes(abstract, sel.SelectionSet, handled, make(map[string]bool))
+				w.visitor.abstractField(parent, fieldDef, abstract, handled, w.usage(sel))
+			}
+			w.walkSelectionSet(unwrapType(fieldDef.Type), sel.SelectionSet)
+
+		case *ast.InlineFragment:
+			narrowed := parent
+			if sel.TypeCondition != nil {
+				if narrowed = w.schema.Type(sel.TypeCondition.Name.Value); narrowed == nil {
+					continue
 				}
 			}
-			return visitor.ActionNoChange, nil
-		},
-	}, nil)
+			w.walkSelectionSet(narrowed, sel.SelectionSet)
 
-	return nil
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			fragment, exists := w.fragments[name]
+			if !exists || w.spreading[name] {
+				continue
+			}
+			narrowed := w.schema.Type(fragment.TypeCondition.Name.Value)
+			if narrowed == nil {
+				continue
+			}
+			w.spreading[name] = true
+			w.walkSelectionSet(narrowed, fragment.SelectionSet)
+			delete(w.spreading, name)
+		}
+	}
 }
 
-// trackFieldUsage tracks the usage of a field in the coverage analysis
-func trackFieldUsage(schema *Schema, field *ast.Field, coverage map[string]*TypeCoverage, parentType *graphql.Object) error {
-	// This is a simplified implementation
-	// In a real implementation, you would need to maintain context about the current type
-	// and traverse the schema to find the correct field
-	
-	fieldName := field.Name.Value
-	
-	// For now, we'll mark any type that has this field name as used
-	for _, typeCoverage := range coverage {
-		if _, exists := typeCoverage.Fields[fieldName]; exists {
-			typeCoverage.Covered = true
-			typeCoverage.Fields[fieldName] = true
-			typeCoverage.UsageCount++
+// collectHandledTypes collects the possible types of an abstract type that the
+// fragments of a selection set target, directly or through another abstract type.
+// Fragments on the abstract type itself handle no type in particular.
+func (w *coverageWalker) collectHandledTypes(abstract graphql.Abstract, selectionSet *ast.SelectionSet, handled map[string]bool, visiting map[string]bool) {
+	for _, selection := range selectionSet.Selections {
+		var condition *ast.Named
+		var nested *ast.SelectionSet
+		spread := ""
+		switch sel := selection.(type) {
+		case *ast.InlineFragment:
+			condition, nested = sel.TypeCondition, sel.SelectionSet
+		case *ast.FragmentSpread:
+			fragment, exists := w.fragments[sel.Name.Value]
+			if !exists || visiting[sel.Name.Value] {
+				continue
+			}
+			spread = sel.Name.Value
+			condition, nested = fragment.TypeCondition, fragment.SelectionSet
+		default:
+			continue
+		}
+
+		if condition != nil && condition.Name.Value != abstract.Name() {
+			switch t := w.schema.Type(condition.Name.Value).(type) {
+			case *graphql.Object:
+				if w.schema.IsPossibleType(abstract, t) {
+					handled[t.Name()] = true
+				}
+			case graphql.Abstract:
+				for _, object := range w.schema.PossibleTypes(t) {
+					if w.schema.IsPossibleType(abstract, object) {
+						handled[object.Name()] = true
+					}
+				}
+			}
+		}
+		if nested != nil {
+			if spread != "" {
+				visiting[spread] = true
+			}
+			w.collectHandledTypes(abstract, nested, handled, visiting)
+			delete(visiting, spread)
 		}
 	}
-	
-	return nil
 }
 
-// calculateCoverageStats calculates the final coverage statistics
+// walkArguments walks the arguments passed to a field and their values
+func (w *coverageWalker) walkArguments(parent graphql.Type, fieldDef *graphql.FieldDefinition, arguments []*ast.Argument) {
+	for _, argument := range arguments {
+		for _, arg := range fieldDef.Args {
+			if arg.Name() != argument.Name.Value {
+				continue
+			}
+			if w.visitor.argument != nil {
+				w.visitor.argument(parent, fieldDef, arg, w.usage(argument))
+			}
+			w.walkValue(argument.Value, arg.Type)
+		}
+	}
+}
+
+// walkValue walks a literal value passed for an input type
+func (w *coverageWalker) walkValue(value ast.Value, t graphql.Type) {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+
+	switch v := value.(type) {
+	case *ast.Variable:
+		w.walkVariable(v, t)
+	case *ast.ListValue:
+		itemType := t
+		if list, ok := t.(*graphql.List); ok {
+			itemType = list.OfType
+		}
+		for _, item := range v.Values {
+			w.walkValue(item, itemType)
+		}
+	default:
+		if list, ok := t.(*graphql.List); ok {
+			// A single value is accepted in place of a list of one
+			w.walkValue(value, list.OfType)
+			return
+		}
+		switch v := value.(type) {
+		case *ast.ObjectValue:
+			if inputObject, ok := t.(*graphql.InputObject); ok {
+				fields := inputObject.Fields()
+				for _, field := range v.Fields {
+					if w.visitor.inputField != nil {
+						w.visitor.inputField(inputObject, field.Name.Value, w.usage(field))
+					}
+					if fieldDef, exists := fields[field.Name.Value]; exists {
+						w.walkValue(field.Value, fieldDef.Type)
+					}
+				}
+			}
+		case *ast.EnumValue:
+			if enum, ok := t.(*graphql.Enum); ok && w.visitor.enumValue != nil {
+				w.visitor.enumValue(enum, v.Value, w.usage(v))
+			}
+		}
+	}
+}
+
+// walkVariable walks the value known for a variable: the one provided with the
+// document, or else its default value. Provided values are attributed to the
+// place the variable is passed.
+func (w *coverageWalker) walkVariable(variable *ast.Variable, t graphql.Type) {
+	name := variable.Name.Value
+	def, defined := w.definitions[name]
+	if defined {
+		if declared := inputTypeFromAST(w.schema, def.Type); declared != nil {
+			t = declared
+		}
+	}
+
+	if value, provided := w.variables[name]; provided {
+		w.walkJSONValue(value, t, variable)
+	} else if defined && def.DefaultValue != nil {
+		w.walkValue(def.DefaultValue, t)
+	} else if w.visitor.unknownVariable != nil {
+		w.visitor.unknownVariable(t)
+	}
+}
+
+// walkJSONValue walks a variable value provided as JSON
+func (w *coverageWalker) walkJSONValue(value interface{}, t graphql.Type, node ast.Node) {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+
+	if list, ok := t.(*graphql.List); ok {
+		if items, ok := value.([]interface{}); ok {
+			for _, item := range items {
+				w.walkJSONValue(item, list.OfType, node)
+			}
+		} else {
+			w.walkJSONValue(value, list.OfType, node)
+		}
+		return
+	}
+
+	switch t := t.(type) {
+	case *graphql.InputObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := t.Fields()
+		for name, fieldValue := range object {
+			if w.visitor.inputField != nil {
+				w.visitor.inputField(t, name, w.usage(node))
+			}
+			if fieldDef, exists := fields[name]; exists {
+				w.walkJSONValue(fieldValue, fieldDef.Type, node)
+			}
+		}
+	case *graphql.Enum:
+		if name, ok := value.(string); ok && w.visitor.enumValue != nil {
+			w.visitor.enumValue(t, name, w.usage(node))
+		}
+	}
+}
+
+// trackFieldUsage credits a field selected on its parent type, and the type the
+// field returns, adding weight to the parent's usage count
+func trackFieldUsage(parent graphql.Type, fieldDef *graphql.FieldDefinition, coverage map[string]*TypeCoverage, weight int64) {
+	if typeCoverage, exists := coverage[parent.Name()]; exists {
+		typeCoverage.Covered = true
+		typeCoverage.Fields[fieldDef.Name] = true
+		typeCoverage.UsageCount += int(weight)
+	}
+	if typeCoverage, exists := coverage[unwrapType(fieldDef.Type).Name()]; exists {
+		typeCoverage.Covered = true
+	}
+}
+
+// zeroTrafficFields lists the fields no request selected, as sorted coordinates
+func zeroTrafficFields(coverage map[string]*TypeCoverage) []string {
+	var fields []string
+	for typeName, typeCoverage := range coverage {
+		for fieldName, hits := range typeCoverage.FieldHits {
+			if hits == 0 {
+				fields = append(fields, typeName+"."+fieldName)
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// calculateCoverageStats calculates the final coverage statistics. Overall
+// coverage is field coverage; the other dimensions are reported by their own counts.
 func calculateCoverageStats(coverage map[string]*TypeCoverage) *CoverageResult {
-	totalTypes := len(coverage)
-	typesCovered := 0
-	totalFields := 0
-	fieldsCovered := 0
+	result := &CoverageResult{
+		TotalTypes: len(coverage),
+		Details:    convertCoverageMap(coverage),
+	}
 
 	for _, typeCoverage := range coverage {
 		if typeCoverage.Covered {
-			typesCovered++
+			result.TypesCovered++
 		}
 
-		for _, covered := range typeCoverage.Fields {
-			totalFields++
-			if covered {
-				fieldsCovered++
+		result.FieldsCovered, result.TotalFields = countCovered(typeCoverage.Fields, result.FieldsCovered, result.TotalFields)
+		for _, hits := range typeCoverage.FieldHits {
+			result.TotalHits += hits
+			if hits > 0 {
+				result.FieldsWithTraffic++
 			}
 		}
+		result.ArgumentsCovered, result.TotalArguments = countCovered(typeCoverage.Arguments, result.ArgumentsCovered, result.TotalArguments)
+		result.EnumValuesCovered, result.TotalEnumValues = countCovered(typeCoverage.EnumValues, result.EnumValuesCovered, result.TotalEnumValues)
+		result.InputFieldsCovered, result.TotalInputFields = countCovered(typeCoverage.InputFields, result.InputFieldsCovered, result.TotalInputFields)
 	}
 
-	var overallCoverage float64
-	if totalFields > 0 {
-		overallCoverage = float64(fieldsCovered) / float64(totalFields)
+	result.Coverage = coverageRatio(result.FieldsCovered, result.TotalFields)
+
+	return result
+}
+
+// countCovered adds the covered and total members of a coverage map to running counts
+func countCovered(members map[string]bool, covered, total int) (int, int) {
+	for _, isCovered := range members {
+		total++
+		if isCovered {
+			covered++
+		}
 	}
+	return covered, total
+}
 
-	return &CoverageResult{
-		Coverage:      overallCoverage,
-		TypesCovered:  typesCovered,
-		TotalTypes:    totalTypes,
-		FieldsCovered: fieldsCovered,
-		TotalFields:   totalFields,
-		Details:       convertCoverageMap(coverage),
+// coverageRatio returns covered/total, or 0 when there is nothing to cover
+func coverageRatio(covered, total int) float64 {
+	if total == 0 {
+		return 0
 	}
+	return float64(covered) / float64(total)
 }
 
 // convertCoverageMap converts the internal coverage map to the public format
@@ -182,14 +616,41 @@ func GenerateCoverageReport(result *CoverageResult) string {
 	report.WriteString(fmt.Sprintf("Types Covered: %d/%d (%.2f%%)\n", 
 		result.TypesCovered, result.TotalTypes, 
 		float64(result.TypesCovered)/float64(result.TotalTypes)*100))
-	report.WriteString(fmt.Sprintf("Fields Covered: %d/%d (%.2f%%)\n\n", 
+	report.WriteString(fmt.Sprintf("Fields Covered: %d/%d (%.2f%%)\n", 
 		result.FieldsCovered, result.TotalFields, 
 		float64(result.FieldsCovered)/float64(result.TotalFields)*100))
+	if result.TotalArguments > 0 {
+		report.WriteString(fmt.Sprintf("Arguments Covered: %d/%d (%.2f%%)\n",
+			result.ArgumentsCovered, result.TotalArguments,
+			coverageRatio(result.ArgumentsCovered, result.TotalArguments)*100))
+	}
+	if result.TotalEnumValues > 0 {
+		report.WriteString(fmt.Sprintf("Enum Values Covered: %d/%d (%.2f%%)\n",
+			result.EnumValuesCovered, result.TotalEnumValues,
+			coverageRatio(result.EnumValuesCovered, result.TotalEnumValues)*100))
+	}
+	if result.TotalInputFields > 0 {
+		report.WriteString(fmt.Sprintf("Input Fields Covered: %d/%d (%.2f%%)\n",
+			result.InputFieldsCovered, result.TotalInputFields,
+			coverageRatio(result.InputFieldsCovered, result.TotalInputFields)*100))
+	}
+	if result.TotalHits > 0 || len(result.ZeroTraffic) > 0 {
+		report.WriteString(fmt.Sprintf("Fields With Traffic: %d/%d (%d hits)\n",
+			result.FieldsWithTraffic, result.TotalFields, result.TotalHits))
+	}
+	report.WriteString("\n")
 
 	report.WriteString("Type Coverage Details:\n")
 	report.WriteString("=====================\n\n")
 
-	for typeName, typeCoverage := range result.Details {
+	typeNames := make([]string, 0, len(result.Details))
+	for typeName := range result.Details {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		typeCoverage := result.Details[typeName]
 		status := "❌ NOT COVERED"
 		if typeCoverage.Covered {
 			status = "✅ COVERED"
@@ -199,7 +660,13 @@ func GenerateCoverageReport(result *CoverageResult) string {
 
 		if len(typeCoverage.Fields) > 0 {
 			report.WriteString("  Fields:\n")
-			for fieldName, covered := range typeCoverage.Fields {
+			fieldNames := make([]string, 0, len(typeCoverage.Fields))
+			for fieldName := range typeCoverage.Fields {
+				fieldNames = append(fieldNames, fieldName)
+			}
+			sort.Strings(fieldNames)
+			for _, fieldName := range fieldNames {
+				covered := typeCoverage.Fields[fieldName]
 				fieldStatus := "❌"
 				if covered {
 					fieldStatus = "✅"
@@ -213,6 +680,50 @@ func GenerateCoverageReport(result *CoverageResult) string {
 	return report.String()
 }
 
+// WhoUses finds the operations using a schema coordinate, such as User.email,
+// User.posts(first:), Role.ADMIN or CreateUserInput.name. A type name finds the
+// usages of each of its members. Usages are keyed by coordinate and sorted by location.
+func WhoUses(schema *Schema, documents []Document, coordinate string) (map[string][]CoordinateUsage, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema is required")
+	}
+	if !hasCoordinate(schema.Schema, coordinate) {
+		return nil, fmt.Errorf("coordinate %s is not defined in the schema", coordinate)
+	}
+
+	result, err := AnalyzeCoverage(schema, documents, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string][]CoordinateUsage)
+	for used, usage := range result.Usages {
+		if used == coordinate || isCoordinateAncestor(coordinate, used) {
+			usages[used] = usage
+		}
+	}
+	for _, usage := range usages {
+		sort.SliceStable(usage, func(i, j int) bool {
+			return compareUsages(usage[i], usage[j]) < 0
+		})
+	}
+
+	return usages, nil
+}
+
+// compareUsages orders usages by source, then location, then operation
+func compareUsages(a, b CoordinateUsage) int {
+	if a.Source != b.Source {
+		return strings.Compare(a.Source, b.Source)
+	}
+	if a.Range != nil && b.Range != nil {
+		if c := comparePositions(a.Range.Start, b.Range.Start); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a.Operation, b.Operation)
+}
+
 // FindUnusedTypes finds types that are not used in any documents
 func FindUnusedTypes(schema *Schema, documents []Document) ([]string, error) {
 	result, err := AnalyzeCoverage(schema, documents, nil)
@@ -253,44 +764,19 @@ func FindUnusedFields(schema *Schema, documents []Document) (map[string][]string
 	return unusedFields, nil
 }
 
-// AnalyzeFieldUsage analyzes how frequently fields are used
+// AnalyzeFieldUsage analyzes how frequently fields are used, by schema coordinate such as User.id
 func AnalyzeFieldUsage(schema *Schema, documents []Document) (map[string]FieldUsage, error) {
 	fieldUsage := make(map[string]FieldUsage)
 
-	for _, doc := range documents {
-		// Parse the document if AST is not provided
-		var docAST *ast.Document
-		if doc.AST != nil {
-			docAST = doc.AST
-		} else {
-			parsed, err := parser.Parse(parser.ParseParams{
-				Source: doc.Content,
-			})
-			if err != nil {
-				continue // Skip invalid documents
-			}
-			docAST = parsed
-		}
-
-		// Visit the document and count field usage
-		visitor.Visit(docAST, &visitor.VisitorOptions{
-			Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
-				if field, ok := p.Node.(*ast.Field); ok {
-					fieldName := field.Name.Value
-					if usage, exists := fieldUsage[fieldName]; exists {
-						usage.Count++
-						fieldUsage[fieldName] = usage
-					} else {
-						fieldUsage[fieldName] = FieldUsage{
-							Field: fieldName,
-							Count: 1,
-						}
-					}
-				}
-				return visitor.ActionNoChange, nil
-			},
-		}, nil)
-	}
+	walkCoverage(schema, documents, &coverageVisitor{
+		field: func(parent graphql.Type, fieldDef *graphql.FieldDefinition, _ CoordinateUsage) {
+			coordinate := parent.Name() + "." + fieldDef.Name
+			usage := fieldUsage[coordinate]
+			usage.Field = coordinate
+			usage.Count++
+			fieldUsage[coordinate] = usage
+		},
+	})
 
 	return fieldUsage, nil
 }
@@ -304,23 +790,41 @@ type FieldUsage struct {
 // GetCoverageSummary returns a summary of coverage statistics
 func GetCoverageSummary(result *CoverageResult) CoverageSummary {
 	return CoverageSummary{
-		OverallCoverage: result.Coverage,
-		TypeCoverage:    float64(result.TypesCovered) / float64(result.TotalTypes),
-		FieldCoverage:   float64(result.FieldsCovered) / float64(result.TotalFields),
-		TotalTypes:      result.TotalTypes,
-		TotalFields:     result.TotalFields,
-		CoveredTypes:    result.TypesCovered,
-		CoveredFields:   result.FieldsCovered,
+		OverallCoverage:    result.Coverage,
+		TypeCoverage:       float64(result.TypesCovered) / float64(result.TotalTypes),
+		FieldCoverage:      float64(result.FieldsCovered) / float64(result.TotalFields),
+		ArgumentCoverage:   coverageRatio(result.ArgumentsCovered, result.TotalArguments),
+		EnumValueCoverage:  coverageRatio(result.EnumValuesCovered, result.TotalEnumValues),
+		InputFieldCoverage: coverageRatio(result.InputFieldsCovered, result.TotalInputFields),
+		TotalTypes:         result.TotalTypes,
+		TotalFields:        result.TotalFields,
+		TotalArguments:     result.TotalArguments,
+		TotalEnumValues:    result.TotalEnumValues,
+		TotalInputFields:   result.TotalInputFields,
+		CoveredTypes:       result.TypesCovered,
+		CoveredFields:      result.FieldsCovered,
+		CoveredArguments:   result.ArgumentsCovered,
+		CoveredEnumValues:  result.EnumValuesCovered,
+		CoveredInputFields: result.InputFieldsCovered,
 	}
 }
 
 // CoverageSummary represents a summary of coverage statistics
 type CoverageSummary struct {
-	OverallCoverage float64 `json:"overallCoverage"`
-	TypeCoverage    float64 `json:"typeCoverage"`
-	FieldCoverage   float64 `json:"fieldCoverage"`
-	TotalTypes      int     `json:"totalTypes"`
-	TotalFields     int     `json:"totalFields"`
-	CoveredTypes    int     `json:"coveredTypes"`
-	CoveredFields   int     `json:"coveredFields"`
-} 
\ No newline at end of file
+	OverallCoverage    float64 `json:"overallCoverage"`
+	TypeCoverage       float64 `json:"typeCoverage"`
+	FieldCoverage      float64 `json:"fieldCoverage"`
+	ArgumentCoverage   float64 `json:"argumentCoverage"`
+	EnumValueCoverage  float64 `json:"enumValueCoverage"`
+	InputFieldCoverage float64 `json:"inputFieldCoverage"`
+	TotalTypes         int     `json:"totalTypes"`
+	TotalFields        int     `json:"totalFields"`
+	TotalArguments     int     `json:"totalArguments"`
+	TotalEnumValues    int     `json:"totalEnumValues"`
+	TotalInputFields   int     `json:"totalInputFields"`
+	CoveredTypes       int     `json:"coveredTypes"`
+	CoveredFields      int     `json:"coveredFields"`
+	CoveredArguments   int     `json:"coveredArguments"`
+	CoveredEnumValues  int     `json:"coveredEnumValues"`
+	CoveredInputFields int     `json:"coveredInputFields"`
+}
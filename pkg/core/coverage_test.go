@@ -0,0 +1,131 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+	"github.com/bishnuag/graphql-inspector/pkg/loader"
+)
+
+func TestAnalyzeCoverageOverallIsFieldCoverage(t *testing.T) {
+	schema := mustLoadSchema(t, `
+type Query { users(first: Int, role: Role, filter: UserFilter): [User] }
+type User { id: ID name: String }
+enum Role { ADMIN USER GUEST }
+input UserFilter { name: String active: Boolean }
+`)
+	documents := mustLoadDocuments(t, `query { users { id name } }`)
+
+	tests := []struct {
+		name    string
+		options *core.CoverageOptions
+	}{
+		{name: "default options"},
+		{
+			name: "every dimension included",
+			options: &core.CoverageOptions{
+				IncludeArguments:   true,
+				IncludeEnumValues:  true,
+				IncludeInputFields: true,
+			},
+		},
+		{name: "no other dimension", options: &core.CoverageOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := core.AnalyzeCoverage(schema, documents, tt.options)
+			if err != nil {
+				t.Fatalf("AnalyzeCoverage() error = %v", err)
+			}
+			if result.Coverage != 1 {
+				t.Errorf("Coverage = %.2f, want field coverage 1.00", result.Coverage)
+			}
+			if tt.options == nil && (result.ArgumentsCovered != 0 || result.TotalArguments != 3) {
+				t.Errorf("arguments = %d/%d, want 0/3", result.ArgumentsCovered, result.TotalArguments)
+			}
+		})
+	}
+}
+
+// mustLoadSchema builds a schema from SDL, failing the test on errors
+func mustLoadSchema(t *testing.T, sdl string) *core.Schema {
+	t.Helper()
+	schema, err := loader.LoadSchemaFromContent(sdl)
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+	return schema
+}
+
+// mustLoadDocuments parses documents, naming them doc1.graphql, doc2.graphql and so on
+func mustLoadDocuments(t *testing.T, contents ...string) []core.Document {
+	t.Helper()
+	documents := make([]core.Document, 0, len(contents))
+	for i, content := range contents {
+		doc, err := loader.LoadDocument(content)
+		if err != nil {
+			t.Fatalf("failed to load document: %v", err)
+		}
+		doc.Source = fmt.Sprintf("doc%d.graphql", i+1)
+		documents = append(documents, *doc)
+	}
+	return documents
+}
+
+func TestAnalyzeCoverageWeightsUsageCountByTraffic(t *testing.T) {
+	schema := mustLoadSchema(t, `
+type Query { user(role: Role): User admin: User }
+type User { id: ID }
+enum Role { ADMIN USER }
+`)
+	documents := mustLoadDocuments(t,
+		`query Home { user(role: USER) { id } }`,
+		`query Admin { admin { id } }`,
+	)
+	traffic := []core.OperationTraffic{
+		{OperationName: "Home", Count: 1000},
+		{OperationName: "Admin", Count: 2},
+	}
+
+	tests := []struct {
+		name    string
+		traffic []core.OperationTraffic
+		want    map[string]int
+		hits    map[string]int64
+	}{
+		{
+			name: "static",
+			want: map[string]int{"Query": 2, "User": 2, "Role": 1},
+		},
+		{
+			name:    "weighted by traffic",
+			traffic: traffic,
+			want:    map[string]int{"Query": 1002, "User": 1002, "Role": 1000},
+			hits:    map[string]int64{"user": 1000, "admin": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := core.AnalyzeCoverage(schema, documents, &core.CoverageOptions{
+				IncludeEnumValues: true,
+				Traffic:           tt.traffic,
+			})
+			if err != nil {
+				t.Fatalf("AnalyzeCoverage() error = %v", err)
+			}
+			for typeName, want := range tt.want {
+				if got := result.Details[typeName].UsageCount; got != want {
+					t.Errorf("%s usage count = %d, want %d", typeName, got, want)
+				}
+			}
+			for fieldName, want := range tt.hits {
+				if got := result.Details["Query"].FieldHits[fieldName]; got != want {
+					t.Errorf("Query.%s hits = %d, want %d", fieldName, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// depthCalculator measures the depth of operations, following fragment spreads
+type depthCalculator struct {
+	fragments map[string]*ast.FragmentDefinition
+	ignore    map[string]bool
+	// spreading holds the fragments being expanded on the current path, so that
+	// fragment cycles terminate instead of recursing forever
+	spreading map[string]bool
+	// measured caches the depth of each fragment, so that fragments spread many
+	// times are only walked once
+	measured map[string]fragmentDepth
+}
+
+// fragmentDepth is the depth of a fragment, the path to its deepest field and the field itself
+type fragmentDepth struct {
+	depth int
+	path  []string
+	field *ast.Field
+}
+
+// newDepthCalculator creates a depth calculator for the fragments of a document.
+// Fields named in ignoreFields, such as the "edges" and "node" wrappers of Relay
+// connections, don't add to the depth.
+func newDepthCalculator(docAST *ast.Document, ignoreFields []string) *depthCalculator {
+	calc := &depthCalculator{
+		fragments: fragmentDefinitions(docAST),
+		ignore:    make(map[string]bool, len(ignoreFields)),
+		spreading: make(map[string]bool),
+		measured:  make(map[string]fragmentDepth),
+	}
+	for _, name := range ignoreFields {
+		calc.ignore[name] = true
+	}
+	return calc
+}
+
+// operationDepth returns the depth of an operation, the path to its deepest field
+// and the field itself
+func (c *depthCalculator) operationDepth(opDef *ast.OperationDefinition) (int, []string, *ast.Field) {
+	return c.selectionSetDepth(opDef.SelectionSet)
+}
+
+// selectionSetDepth returns the depth of the deepest field in a selection set, its path and the field
+func (c *depthCalculator) selectionSetDepth(selectionSet *ast.SelectionSet) (int, []string, *ast.Field) {
+	if selectionSet == nil {
+		return 0, nil, nil
+	}
+
+	maxDepth := 0
+	var maxPath []string
+	var maxField *ast.Field
+
+	for _, selection := range selectionSet.Selections {
+		var depth int
+		var path []string
+		var deepest *ast.Field
+
+		switch sel := selection.(type) {
+		case *ast.Field:
+			childDepth, childPath, childField := c.selectionSetDepth(sel.SelectionSet)
+			depth = childDepth
+			if !c.ignore[sel.Name.Value] {
+				depth++
+			}
+			path = append([]string{responseName(sel)}, childPath...)
+			deepest = childField
+			if deepest == nil {
+				deepest = sel
+			}
+		case *ast.InlineFragment:
+			depth, path, deepest = c.selectionSetDepth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			if measured, cached := c.measured[name]; cached {
+				depth, path, deepest = measured.depth, measured.path, measured.field
+				break
+			}
+			fragment, exists := c.fragments[name]
+			if !exists || c.spreading[name] {
+				continue
+			}
+			c.spreading[name] = true
+			depth, path, deepest = c.selectionSetDepth(fragment.SelectionSet)
+			delete(c.spreading, name)
+			// Paths are never modified in place, so cached ones can be shared. Within a
+			// fragment cycle the cached depth is cut short, but cycles are reported as
+			// invalid by the specification rules anyway.
+			c.measured[name] = fragmentDepth{depth: depth, path: path, field: deepest}
+		}
+
+		if depth > maxDepth || maxPath == nil {
+			maxDepth = depth
+			maxPath = path
+			maxField = deepest
+		}
+	}
+
+	return maxDepth, maxPath, maxField
+}
+
+// fragmentDefinitions indexes the fragment definitions of a document by name
+func fragmentDefinitions(docAST *ast.Document) map[string]*ast.FragmentDefinition {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range docAST.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	return fragments
+}
+
+// responseName returns the alias of a field, or its name when it isn't aliased
+func responseName(field *ast.Field) string {
+	if field.Alias != nil {
+		return field.Alias.Value
+	}
+	return field.Name.Value
+}
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TextEditOp represents the kind of a word-level edit
+type TextEditOp string
+
+const (
+	// Words present in both texts
+	TextEditEqual TextEditOp = "equal"
+	// Words only present in the new text
+	TextEditInsert TextEditOp = "insert"
+	// Words only present in the old text
+	TextEditDelete TextEditOp = "delete"
+)
+
+// TextEdit represents a run of words that were kept, inserted or deleted
+type TextEdit struct {
+	Op   TextEditOp `json:"op"`
+	Text string     `json:"text"`
+}
+
+// markdownFormatting matches paired inline Markdown markup that doesn't change the
+// meaning of a description. Underscores only count at word boundaries, so that
+// identifiers such as user_id are left alone.
+var markdownFormatting = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile("`([^`\n]+)`"), "$1"},
+	{regexp.MustCompile(`\*\*\*([^*\s](?:[^*]*[^*\s])?)\*\*\*`), "$1"},
+	{regexp.MustCompile(`\*\*([^*\s](?:[^*]*[^*\s])?)\*\*`), "$1"},
+	{regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`), "$1"},
+	{regexp.MustCompile(`(^|\W)___([^_\s](?:[^_]*[^_\s])?)___(\W|$)`), "$1$2$3"},
+	{regexp.MustCompile(`(^|\W)__([^_\s](?:[^_]*[^_\s])?)__(\W|$)`), "$1$2$3"},
+	{regexp.MustCompile(`(^|\W)_([^_\s](?:[^_]*[^_\s])?)_(\W|$)`), "$1$2$3"},
+	{regexp.MustCompile(`~~([^~\s](?:[^~]*[^~\s])?)~~`), "$1"},
+}
+
+// stripMarkdownFormatting removes paired inline Markdown markup from a text
+func stripMarkdownFormatting(text string) string {
+	for _, formatting := range markdownFormatting {
+		// Boundary characters are part of a match, so adjacent spans need another pass
+		for {
+			stripped := formatting.pattern.ReplaceAllString(text, formatting.replacement)
+			if stripped == text {
+				break
+			}
+			text = stripped
+		}
+	}
+	return text
+}
+
+// descriptionsEqual compares two descriptions, optionally ignoring whitespace and Markdown formatting
+func descriptionsEqual(oldDesc, newDesc string, options *DiffOptions) bool {
+	if oldDesc == newDesc {
+		return true
+	}
+	return normalizeDescription(oldDesc, options) == normalizeDescription(newDesc, options)
+}
+
+// normalizeDescription strips the whitespace and Markdown formatting differences the options ignore
+func normalizeDescription(desc string, options *DiffOptions) string {
+	if options.IgnoreDescriptionFormatting {
+		desc = stripMarkdownFormatting(desc)
+	}
+	if options.IgnoreDescriptionWhitespace || options.IgnoreDescriptionFormatting {
+		desc = strings.Join(strings.Fields(desc), " ")
+	}
+	return desc
+}
+
+// DiffWords computes a word-level diff between two texts, merging adjacent words
+// with the same edit into a single run
+func DiffWords(oldText, newText string) []TextEdit {
+	oldWords := strings.Fields(oldText)
+	newWords := strings.Fields(newText)
+
+	// Longest common subsequence table over the word lists
+	lcs := make([][]int, len(oldWords)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newWords)+1)
+	}
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if oldWords[i] == newWords[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var edits []TextEdit
+	add := func(op TextEditOp, word string) {
+		if n := len(edits); n > 0 && edits[n-1].Op == op {
+			edits[n-1].Text += " " + word
+			return
+		}
+		edits = append(edits, TextEdit{Op: op, Text: word})
+	}
+
+	i, j := 0, 0
+	for i < len(oldWords) && j < len(newWords) {
+		switch {
+		case oldWords[i] == newWords[j]:
+			add(TextEditEqual, oldWords[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(TextEditDelete, oldWords[i])
+			i++
+		default:
+			add(TextEditInsert, newWords[j])
+			j++
+		}
+	}
+	for ; i < len(oldWords); i++ {
+		add(TextEditDelete, oldWords[i])
+	}
+	for ; j < len(newWords); j++ {
+		add(TextEditInsert, newWords[j])
+	}
+
+	return edits
+}
+
+// CompactTextEdits shortens unchanged runs to a few words of context around each
+// edit, replacing the elided words with "…"
+func CompactTextEdits(edits []TextEdit, context int) []TextEdit {
+	if len(edits) <= 1 {
+		return edits
+	}
+
+	compacted := make([]TextEdit, 0, len(edits))
+
+	for i, edit := range edits {
+		if edit.Op != TextEditEqual {
+			compacted = append(compacted, edit)
+			continue
+		}
+
+		words := strings.Fields(edit.Text)
+		keepBefore, keepAfter := context, context
+		if i == 0 {
+			keepBefore = 0
+		}
+		if i == len(edits)-1 {
+			keepAfter = 0
+		}
+		if len(words) <= keepBefore+keepAfter+1 {
+			compacted = append(compacted, edit)
+			continue
+		}
+
+		var parts []string
+		parts = append(parts, words[:keepBefore]...)
+		parts = append(parts, "…")
+		parts = append(parts, words[len(words)-keepAfter:]...)
+		compacted = append(compacted, TextEdit{Op: TextEditEqual, Text: strings.Join(parts, " ")})
+	}
+
+	return compacted
+}
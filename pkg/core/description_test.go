@@ -0,0 +1,144 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescriptionsEqualIgnoringFormatting(t *testing.T) {
+	options := &DiffOptions{IgnoreDescriptionFormatting: true}
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     bool
+	}{
+		{"emphasis", "The user's name", "The *user's* name", true},
+		{"strong emphasis", "The user's name", "The **user's** name", true},
+		{"underscore emphasis", "The user's name", "The __user's__ _name_", true},
+		{"code spans", "Filter by user_id", "Filter by `user_id`", true},
+		{"strikethrough", "Use name", "Use ~~name~~", true},
+		{"adjacent spans", "a b", "_a_ _b_", true},
+		{"whitespace", "The  user's\nname", "The user's name", true},
+		{"underscores inside identifiers", "Filter by user_id", "Filter by userid", false},
+		{"identifier renames inside code spans", "Filter by `user_id`", "Filter by `userid`", false},
+		{"unpaired markers", "Costs 2 * 3", "Costs 2 3", false},
+		{"wording changes", "The user's name", "The *account's* name", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descriptionsEqual(tt.old, tt.new, options); got != tt.want {
+				t.Errorf("descriptionsEqual(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []TextEdit
+	}{
+		{
+			name: "identical texts",
+			old:  "The name of the user",
+			new:  "The name  of\nthe user",
+			want: []TextEdit{{TextEditEqual, "The name of the user"}},
+		},
+		{
+			name: "replaced words",
+			old:  "The name of the user",
+			new:  "The full name of the account",
+			want: []TextEdit{
+				{TextEditEqual, "The"},
+				{TextEditInsert, "full"},
+				{TextEditEqual, "name of the"},
+				{TextEditDelete, "user"},
+				{TextEditInsert, "account"},
+			},
+		},
+		{
+			name: "added text",
+			old:  "",
+			new:  "The user",
+			want: []TextEdit{{TextEditInsert, "The user"}},
+		},
+		{
+			name: "removed text",
+			old:  "The user",
+			new:  "",
+			want: []TextEdit{{TextEditDelete, "The user"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffWords(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffWords() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactTextEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		edits []TextEdit
+		want  []TextEdit
+	}{
+		{
+			name:  "a single edit is kept",
+			edits: []TextEdit{{TextEditEqual, "one two three four five six"}},
+			want:  []TextEdit{{TextEditEqual, "one two three four five six"}},
+		},
+		{
+			name: "leading and trailing runs keep context next to the edit",
+			edits: []TextEdit{
+				{TextEditEqual, "one two three four"},
+				{TextEditInsert, "new"},
+				{TextEditEqual, "five six seven eight"},
+			},
+			want: []TextEdit{
+				{TextEditEqual, "… three four"},
+				{TextEditInsert, "new"},
+				{TextEditEqual, "five six …"},
+			},
+		},
+		{
+			name: "runs between edits keep context on both sides",
+			edits: []TextEdit{
+				{TextEditDelete, "old"},
+				{TextEditEqual, "one two three four five six"},
+				{TextEditInsert, "new"},
+			},
+			want: []TextEdit{
+				{TextEditDelete, "old"},
+				{TextEditEqual, "one two … five six"},
+				{TextEditInsert, "new"},
+			},
+		},
+		{
+			name: "short runs are kept whole",
+			edits: []TextEdit{
+				{TextEditDelete, "old"},
+				{TextEditEqual, "one two three four five"},
+				{TextEditInsert, "new"},
+			},
+			want: []TextEdit{
+				{TextEditDelete, "old"},
+				{TextEditEqual, "one two three four five"},
+				{TextEditInsert, "new"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompactTextEdits(tt.edits, 2); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompactTextEdits() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
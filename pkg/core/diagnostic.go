@@ -0,0 +1,140 @@
+package core
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+)
+
+// Severity represents how serious a diagnostic is
+type Severity string
+
+const (
+	// Problems that make a document invalid
+	SeverityError Severity = "error"
+	// Problems worth fixing that don't make a document invalid
+	SeverityWarning Severity = "warning"
+	// Informational findings
+	SeverityInfo Severity = "info"
+)
+
+// Position represents a 1-based line and column in a document
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Range represents the span of a document a diagnostic points to
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Diagnostic represents a problem found in a document by a validation rule
+type Diagnostic struct {
+	RuleID     string   `json:"ruleId"`
+	Severity   Severity `json:"severity"`
+	Message    string   `json:"message"`
+	Source     string   `json:"source"`
+	Operation  string   `json:"operation,omitempty"`
+	Coordinate string   `json:"coordinate,omitempty"`
+	Range      *Range   `json:"range,omitempty"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+// nodeRange returns the range a node spans in its source, or nil without location info
+func nodeRange(node ast.Node) *Range {
+	if node == nil {
+		return nil
+	}
+	loc := node.GetLoc()
+	if loc == nil || loc.Source == nil {
+		return nil
+	}
+	start := location.GetLocation(loc.Source, loc.Start)
+	end := location.GetLocation(loc.Source, loc.End)
+	return &Range{
+		Start: Position{Line: start.Line, Column: start.Column},
+		End:   Position{Line: end.Line, Column: end.Column},
+	}
+}
+
+// nodeRangeOr returns the range of a node, or of a fallback node when it is nil
+func nodeRangeOr(node *ast.Field, fallback ast.Node) *Range {
+	if node == nil {
+		return nodeRange(fallback)
+	}
+	return nodeRange(node)
+}
+
+// locationsRange returns a zero-width range at the first of a set of error locations
+func locationsRange(locations []location.SourceLocation) *Range {
+	if len(locations) == 0 {
+		return nil
+	}
+	position := Position{Line: locations[0].Line, Column: locations[0].Column}
+	return &Range{Start: position, End: position}
+}
+
+// syntaxErrorDiagnostic converts a parse error into a diagnostic
+func syntaxErrorDiagnostic(err error) Diagnostic {
+	diagnostic := Diagnostic{
+		RuleID:   "syntax",
+		Severity: SeverityError,
+		Message:  err.Error(),
+	}
+
+	var gqlErr *gqlerrors.Error
+	if errors.As(err, &gqlErr) {
+		diagnostic.Range = locationsRange(gqlErr.Locations)
+	}
+	// Syntax errors quote the offending source after the first line
+	if i := strings.Index(diagnostic.Message, "\n"); i >= 0 {
+		diagnostic.Message = diagnostic.Message[:i]
+	}
+
+	return diagnostic
+}
+
+// operationAt returns the name of the operation spanning a line and column, if any
+func operationAt(docAST *ast.Document, position Position) string {
+	for _, def := range docAST.Definitions {
+		opDef, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		r := nodeRange(opDef)
+		if r == nil {
+			continue
+		}
+		if comparePositions(r.Start, position) <= 0 && comparePositions(position, r.End) <= 0 {
+			return getOperationName(opDef)
+		}
+	}
+	return ""
+}
+
+// comparePositions orders two positions, returning -1, 0 or 1
+func comparePositions(a, b Position) int {
+	switch {
+	case a.Line < b.Line, a.Line == b.Line && a.Column < b.Column:
+		return -1
+	case a == b:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// HasErrors reports whether any diagnostic is an error
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
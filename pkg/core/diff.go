@@ -2,7 +2,6 @@ package core
 
 import (
 	"fmt"
-	"reflect"
 	"sort"
 
 	"github.com/graphql-go/graphql"
@@ -34,6 +33,12 @@ func DiffSchemas(oldSchema, newSchema *Schema, options *DiffOptions) ([]Change,
 	schemaChanges := compareSchemaDefinition(oldSchema.Schema, newSchema.Schema, options)
 	changes = append(changes, schemaChanges...)
 
+	// Flag changes to types no operation can reach
+	changes = annotateReachability(changes, oldSchema.Schema, newSchema.Schema, options)
+
+	// Drop changes outside the parts of the schema we were asked about
+	changes = FilterChanges(changes, oldSchema, newSchema, options.Include, options.Exclude)
+
 	// Sort changes by criticality and path
 	sort.Slice(changes, func(i, j int) bool {
 		if changes[i].Type != changes[j].Type {
@@ -54,6 +59,9 @@ func compareTypes(oldSchema, newSchema *graphql.Schema, options *DiffOptions) []
 
 	// Find removed types
 	for name, oldType := range oldTypes {
+		if isBuiltInType(name) {
+			continue // Built-in types come and go with their first use
+		}
 		if _, exists := newTypes[name]; !exists {
 			changes = append(changes, Change{
 				Type:        ChangeTypeBreaking,
@@ -70,6 +78,9 @@ func compareTypes(oldSchema, newSchema *graphql.Schema, options *DiffOptions) []
 
 	// Find added types
 	for name, newType := range newTypes {
+		if isBuiltInType(name) {
+			continue
+		}
 		if _, exists := oldTypes[name]; !exists {
 			changes = append(changes, Change{
 				Type:        ChangeTypeNonBreaking,
@@ -151,12 +162,17 @@ func compareObjectType(typeName string, oldType, newType *graphql.Object, option
 	var changes []Change
 
 	// Compare description
-	if !options.IgnoreDescriptions && oldType.Description() != newType.Description() {
+	if !options.IgnoreDescriptions && !descriptionsEqual(oldType.Description(), newType.Description(), options) {
 		changes = append(changes, Change{
 			Type:        ChangeTypeNonBreaking,
 			Message:     fmt.Sprintf("Description for type '%s' changed", typeName),
 			Path:        typeName,
 			Criticality: "LOW",
+			Meta: map[string]interface{}{
+				"typeName":       typeName,
+				"oldDescription": oldType.Description(),
+				"newDescription": newType.Description(),
+			},
 		})
 	}
 
@@ -192,7 +208,7 @@ func compareFields(typeName string, oldFields, newFields graphql.FieldDefinition
 	}
 
 	// Find added fields
-	for fieldName := range newFields {
+	for fieldName, newField := range newFields {
 		if _, exists := oldFields[fieldName]; !exists {
 			changes = append(changes, Change{
 				Type:        ChangeTypeNonBreaking,
@@ -202,6 +218,7 @@ func compareFields(typeName string, oldFields, newFields graphql.FieldDefinition
 				Meta: map[string]interface{}{
 					"typeName":  typeName,
 					"fieldName": fieldName,
+					"fieldType": getTypeString(newField.Type),
 				},
 			})
 		}
@@ -248,12 +265,18 @@ func compareField(typeName, fieldName string, oldField, newField *graphql.FieldD
 	}
 
 	// Compare field description
-	if !options.IgnoreDescriptions && oldField.Description != newField.Description {
+	if !options.IgnoreDescriptions && !descriptionsEqual(oldField.Description, newField.Description, options) {
 		changes = append(changes, Change{
 			Type:        ChangeTypeNonBreaking,
 			Message:     fmt.Sprintf("Field '%s.%s' description changed", typeName, fieldName),
 			Path:        fmt.Sprintf("%s.%s", typeName, fieldName),
 			Criticality: "LOW",
+			Meta: map[string]interface{}{
+				"typeName":       typeName,
+				"fieldName":      fieldName,
+				"oldDescription": oldField.Description,
+				"newDescription": newField.Description,
+			},
 		})
 	}
 
@@ -375,8 +398,10 @@ func getTypeString(t graphql.Type) string {
 	}
 }
 
+// areTypesEqual compares type references by their SDL form, since types
+// from two schemas are distinct values even when they are identical
 func areTypesEqual(oldType, newType graphql.Type) bool {
-	return reflect.DeepEqual(oldType, newType)
+	return getTypeString(oldType) == getTypeString(newType)
 }
 
 func isTypeWidening(oldType, newType graphql.Type) bool {
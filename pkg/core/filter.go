@@ -0,0 +1,109 @@
+package core
+
+import (
+	"path"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// FilterChanges keeps the changes whose coordinates match the include patterns
+// (all changes when none are given) and none of the exclude patterns.
+//
+// Patterns are schema-coordinate globs such as "Billing*" or "Query.admin*",
+// which also match everything nested under a matching coordinate, or directives
+// such as "@internal" or `@tag(name: "public")`, which match coordinates carrying
+// that directive in either schema, along with everything nested under them.
+func FilterChanges(changes []Change, oldSchema, newSchema *Schema, include, exclude []string) []Change {
+	if len(include) == 0 && len(exclude) == 0 {
+		return changes
+	}
+
+	var directives map[string][]*ast.Directive
+	if hasDirectivePattern(include) || hasDirectivePattern(exclude) {
+		directives = mergedDirectiveIndex(oldSchema, newSchema)
+	}
+
+	filtered := make([]Change, 0, len(changes))
+	for _, change := range changes {
+		if len(include) > 0 && !matchesAnyCoordinatePattern(change.Path, include, directives) {
+			continue
+		}
+		if matchesAnyCoordinatePattern(change.Path, exclude, directives) {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+
+	return filtered
+}
+
+// matchesAnyCoordinatePattern reports whether a coordinate, or any coordinate enclosing it, matches a pattern
+func matchesAnyCoordinatePattern(coordinate string, patterns []string, directives map[string][]*ast.Directive) bool {
+	if coordinate == "" {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		for c := coordinate; c != ""; c = parentCoordinate(c) {
+			if matchesCoordinatePattern(c, pattern, directives) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesCoordinatePattern matches a single coordinate against a glob or directive pattern
+func matchesCoordinatePattern(coordinate, pattern string, directives map[string][]*ast.Directive) bool {
+	if strings.HasPrefix(pattern, "@") {
+		return matchesDirectivePattern(directives[coordinate], pattern)
+	}
+
+	matched, err := path.Match(pattern, coordinate)
+	return err == nil && matched
+}
+
+// matchesDirectivePattern reports whether a directive list has a directive matching
+// a pattern such as "@internal", or `@tag(name: "public")` to match arguments too
+func matchesDirectivePattern(directives []*ast.Directive, pattern string) bool {
+	name, _, hasArguments := strings.Cut(strings.TrimPrefix(pattern, "@"), "(")
+	if !hasArguments {
+		return hasDirective(directives, name)
+	}
+
+	// Compare the printed directive, ignoring whitespace
+	want := strings.Join(strings.Fields(pattern), "")
+	for _, directive := range directives {
+		if directive.Name != nil && directive.Name.Value == name && strings.Join(strings.Fields(printNode(directive)), "") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDirectivePattern reports whether any pattern matches by directive
+func hasDirectivePattern(patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "@") {
+			return true
+		}
+	}
+	return false
+}
+
+// mergedDirectiveIndex indexes directives from both schemas, so removed and added
+// coordinates can be matched by directive too
+func mergedDirectiveIndex(schemas ...*Schema) map[string][]*ast.Directive {
+	merged := make(map[string][]*ast.Directive)
+	for _, schema := range schemas {
+		doc, err := parseSchemaSDL(schema)
+		if err != nil {
+			continue // Schemas built in code have no SDL to read directives from
+		}
+		for coordinate, directives := range directivesByCoordinate(doc) {
+			merged[coordinate] = append(merged[coordinate], directives...)
+		}
+	}
+	return merged
+}
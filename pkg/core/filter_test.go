@@ -0,0 +1,109 @@
+package core_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestFilterChanges(t *testing.T) {
+	oldSchema := mustLoadSchema(t, `
+directive @internal on FIELD_DEFINITION | OBJECT
+directive @tag(name: String) on FIELD_DEFINITION
+type Query {
+  billingInfo: Billing
+  admin: String @internal
+  legacy: String @internal
+  users(first: Int): [String] @tag(name: "public")
+}
+type Billing { id: ID }
+type BillingAccount { owner: String }
+`)
+	newSchema := mustLoadSchema(t, `
+directive @internal on FIELD_DEFINITION | OBJECT
+directive @tag(name: String) on FIELD_DEFINITION
+type Query {
+  billingInfo: Billing
+  admin: String @internal
+  users(first: Int): [String] @tag(name: "public")
+}
+type Billing { id: ID }
+type BillingAccount { owner: String }
+`)
+
+	var changes []core.Change
+	for _, path := range []string{
+		"Billing",
+		"Billing.id",
+		"BillingAccount.owner",
+		"Query.billingInfo",
+		"Query.admin",
+		"Query.legacy",
+		"Query.users",
+		"Query.users(first:)",
+	} {
+		changes = append(changes, core.Change{Type: core.ChangeTypeBreaking, Path: path})
+	}
+
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             []string
+	}{
+		{
+			name:    "globs match coordinates and everything nested under them",
+			include: []string{"Billing*"},
+			want:    []string{"Billing", "Billing.id", "BillingAccount.owner"},
+		},
+		{
+			name:    "field globs",
+			include: []string{"Query.billing*"},
+			want:    []string{"Query.billingInfo"},
+		},
+		{
+			name:    "directive patterns match either schema",
+			include: []string{"@internal"},
+			want:    []string{"Query.admin", "Query.legacy"},
+		},
+		{
+			name:    "directive patterns with arguments match nested coordinates",
+			include: []string{`@tag(name: "public")`},
+			want:    []string{"Query.users", "Query.users(first:)"},
+		},
+		{
+			name:    "directive patterns with other arguments don't match",
+			include: []string{`@tag(name: "private")`},
+			want:    []string{},
+		},
+		{
+			name:    "excluded directives",
+			exclude: []string{"@internal"},
+			want:    []string{"Billing", "Billing.id", "BillingAccount.owner", "Query.billingInfo", "Query.users", "Query.users(first:)"},
+		},
+		{
+			name:    "exclude takes precedence over include",
+			include: []string{"Billing*"},
+			exclude: []string{"Billing.id"},
+			want:    []string{"Billing", "BillingAccount.owner"},
+		},
+		{
+			name:    "excluding a parent excludes included children",
+			include: []string{"Query.users"},
+			exclude: []string{"Query"},
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, change := range core.FilterChanges(changes, oldSchema, newSchema, tt.include, tt.exclude) {
+				got = append(got, change.Path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// fragmentIndex indexes fragment definitions across a set of documents, so that
+// operations can spread fragments defined in other files
+type fragmentIndex struct {
+	definitions map[string][]fragmentLocation
+	// first holds the first definition of each fragment, used to resolve spreads
+	first map[string]*ast.FragmentDefinition
+	// used holds the fragments spread by any operation in the set
+	used map[string]bool
+}
+
+// fragmentLocation is a fragment definition and the document defining it
+type fragmentLocation struct {
+	source     string
+	definition *ast.FragmentDefinition
+}
+
+// parsedDocument is a document along with its AST, or the error parsing it
+type parsedDocument struct {
+	doc Document
+	ast *ast.Document
+	err error
+}
+
+// parseDocument returns the AST of a document, parsing its content if needed
+func parseDocument(doc Document) (*ast.Document, error) {
+	if doc.AST != nil {
+		return doc.AST, nil
+	}
+	return parser.Parse(parser.ParseParams{
+		Source: doc.Content,
+	})
+}
+
+// parseDocuments parses every document of a set
+func parseDocuments(documents []Document) []parsedDocument {
+	parsed := make([]parsedDocument, 0, len(documents))
+	for _, doc := range documents {
+		docAST, err := parseDocument(doc)
+		parsed = append(parsed, parsedDocument{doc: doc, ast: docAST, err: err})
+	}
+	return parsed
+}
+
+// newFragmentIndex indexes the fragments of the documents that parsed
+func newFragmentIndex(documents []parsedDocument) *fragmentIndex {
+	index := &fragmentIndex{
+		definitions: make(map[string][]fragmentLocation),
+		first:       make(map[string]*ast.FragmentDefinition),
+		used:        make(map[string]bool),
+	}
+
+	for _, parsed := range documents {
+		if parsed.ast == nil {
+			continue
+		}
+		for _, def := range parsed.ast.Definitions {
+			fragment, ok := def.(*ast.FragmentDefinition)
+			if !ok {
+				continue
+			}
+			name := fragment.Name.Value
+			index.definitions[name] = append(index.definitions[name], fragmentLocation{source: parsed.doc.Source, definition: fragment})
+			if _, exists := index.first[name]; !exists {
+				index.first[name] = fragment
+			}
+		}
+	}
+
+	for _, parsed := range documents {
+		if parsed.ast == nil {
+			continue
+		}
+		fragments := index.resolver(parsed.ast)
+		for _, def := range parsed.ast.Definitions {
+			if opDef, ok := def.(*ast.OperationDefinition); ok {
+				for name := range usedFragments(opDef.SelectionSet, fragments) {
+					index.used[name] = true
+				}
+			}
+		}
+	}
+
+	return index
+}
+
+// resolver returns the fragments visible to a document: its own, then those of the rest of the set
+func (index *fragmentIndex) resolver(docAST *ast.Document) map[string]*ast.FragmentDefinition {
+	fragments := make(map[string]*ast.FragmentDefinition, len(index.first))
+	for name, fragment := range index.first {
+		fragments[name] = fragment
+	}
+	for name, fragment := range fragmentDefinitions(docAST) {
+		fragments[name] = fragment
+	}
+	return fragments
+}
+
+// withExternalFragments returns a document extended with the fragments it spreads,
+// directly or transitively, that other documents define
+func (index *fragmentIndex) withExternalFragments(docAST *ast.Document) *ast.Document {
+	local := fragmentDefinitions(docAST)
+	fragments := index.resolver(docAST)
+
+	needed := make(map[string]bool)
+	for _, def := range docAST.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			for name := range usedFragments(def.SelectionSet, fragments) {
+				needed[name] = true
+			}
+		case *ast.FragmentDefinition:
+			for name := range usedFragments(def.SelectionSet, fragments) {
+				needed[name] = true
+			}
+		}
+	}
+
+	var external []string
+	for name := range needed {
+		if _, defined := local[name]; !defined && index.first[name] != nil {
+			external = append(external, name)
+		}
+	}
+	if len(external) == 0 {
+		return docAST
+	}
+	sort.Strings(external)
+
+	definitions := make([]ast.Node, 0, len(docAST.Definitions)+len(external))
+	definitions = append(definitions, docAST.Definitions...)
+	for _, name := range external {
+		definitions = append(definitions, index.first[name])
+	}
+
+	return ast.NewDocument(&ast.Document{
+		Loc:         docAST.Loc,
+		Definitions: definitions,
+	})
+}
+
+// duplicateDiagnostics reports fragments defined in more than one document, at each definition
+func (index *fragmentIndex) duplicateDiagnostics() map[string][]Diagnostic {
+	bySource := make(map[string][]Diagnostic)
+
+	names := make([]string, 0, len(index.definitions))
+	for name := range index.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		locations := index.definitions[name]
+		sources := make(map[string]bool)
+		for _, location := range locations {
+			sources[location.source] = true
+		}
+		if len(sources) < 2 {
+			continue // Duplicates within a document are reported by the specification rules
+		}
+
+		for _, location := range locations {
+			var others []string
+			for source := range sources {
+				if source != location.source {
+					others = append(others, source)
+				}
+			}
+			sort.Strings(others)
+			bySource[location.source] = append(bySource[location.source], Diagnostic{
+				RuleID:     "unique-fragment-names",
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("Fragment '%s' is also defined in %s", name, strings.Join(others, ", ")),
+				Source:     location.source,
+				Range:      nodeRange(location.definition),
+				Suggestion: "Rename one of the fragments",
+			})
+		}
+	}
+
+	return bySource
+}
+
+// isLocalNode reports whether a node belongs to the source of a document, rather
+// than to a fragment merged in from another document
+func isLocalNode(node ast.Node, docAST *ast.Document) bool {
+	if node == nil || docAST.Loc == nil || docAST.Loc.Source == nil {
+		return true
+	}
+	loc := node.GetLoc()
+	return loc == nil || loc.Source == nil || loc.Source == docAST.Loc.Source
+}
+
+// containing returns the fragment definition, and its document, that a node belongs to
+func (index *fragmentIndex) containing(node ast.Node) *fragmentLocation {
+	loc := node.GetLoc()
+	if loc == nil || loc.Source == nil {
+		return nil
+	}
+	for _, locations := range index.definitions {
+		for i, location := range locations {
+			defLoc := location.definition.GetLoc()
+			if defLoc != nil && defLoc.Source == loc.Source && defLoc.Start <= loc.Start && loc.End <= defLoc.End {
+				return &locations[i]
+			}
+		}
+	}
+	return nil
+}
+
+// spreadingOperation returns the name of the first operation of a document that
+// spreads a fragment, directly or through other fragments
+func spreadingOperation(docAST *ast.Document, name string, fragments map[string]*ast.FragmentDefinition) string {
+	for _, def := range docAST.Definitions {
+		if opDef, ok := def.(*ast.OperationDefinition); ok && usedFragments(opDef.SelectionSet, fragments)[name] {
+			return getOperationName(opDef)
+		}
+	}
+	return ""
+}
+
+// specErrorKey identifies a specification error raised at a node, whichever
+// document was being validated
+type specErrorKey struct {
+	source  *source.Source
+	start   int
+	message string
+}
+
+// newSpecErrorKey returns the key of an error raised at a node with location info
+func newSpecErrorKey(node ast.Node, err gqlerrors.FormattedError) specErrorKey {
+	loc := node.GetLoc()
+	if loc == nil {
+		return specErrorKey{message: err.Message}
+	}
+	return specErrorKey{source: loc.Source, start: loc.Start, message: err.Message}
+}
+
+// errorNode returns the first node a specification error was raised at, if any
+func errorNode(err gqlerrors.FormattedError) ast.Node {
+	if gqlErr, ok := err.OriginalError().(*gqlerrors.Error); ok && len(gqlErr.Nodes) > 0 {
+		return gqlErr.Nodes[0]
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestValidateDocumentsSharedFragmentErrors(t *testing.T) {
+	schema := mustLoadSchema(t, `type Query { user(id: ID): User } type User { id: ID name: String }`)
+
+	tests := []struct {
+		name      string
+		fragment  string
+		operation string
+		// want holds the message fragments expected in each document, keyed by source
+		want map[string][]string
+	}{
+		{
+			name:      "undefined variable is reported where the fragment is spread",
+			fragment:  `fragment UserById on Query { user(id: $id) { id } }`,
+			operation: `query GetUser { ...UserById }`,
+			want: map[string][]string{
+				"doc2.graphql": {`Variable "$id" is not defined by operation "GetUser".`, "(in fragment 'UserById' from doc1.graphql)"},
+			},
+		},
+		{
+			name:      "errors the fragment's document reports are not repeated",
+			fragment:  `fragment UserFields on User { nope }`,
+			operation: `query GetUser { user { ...UserFields } }`,
+			want: map[string][]string{
+				"doc1.graphql": {`Cannot query field "nope" on type "User".`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := core.ValidateDocuments(schema, mustLoadDocuments(t, tt.fragment, tt.operation), &core.ValidateOptions{})
+			if err != nil {
+				t.Fatalf("ValidateDocuments() error = %v", err)
+			}
+
+			for _, result := range results {
+				want := tt.want[result.Source]
+				if result.IsValid != (len(want) == 0) {
+					t.Errorf("%s IsValid = %v, diagnostics %+v", result.Source, result.IsValid, result.Diagnostics)
+				}
+				if len(want) == 0 {
+					continue
+				}
+				if len(result.Diagnostics) != 1 {
+					t.Fatalf("%s diagnostics = %+v, want 1", result.Source, result.Diagnostics)
+				}
+				diagnostic := result.Diagnostics[0]
+				for _, part := range want {
+					if !strings.Contains(diagnostic.Message, part) {
+						t.Errorf("%s message = %q, want it to contain %q", result.Source, diagnostic.Message, part)
+					}
+				}
+				if strings.Contains(diagnostic.Message, "in fragment") && diagnostic.Operation != "GetUser" {
+					t.Errorf("%s operation = %q, want the spreading operation GetUser", result.Source, diagnostic.Operation)
+				}
+				if diagnostic.Range == nil || diagnostic.Range.Start.Line != 1 {
+					t.Errorf("%s range = %+v, want the fragment's location", result.Source, diagnostic.Range)
+				}
+			}
+		})
+	}
+}
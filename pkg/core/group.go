@@ -0,0 +1,119 @@
+package core
+
+import (
+	"sort"
+)
+
+// ChangeGroup represents a change together with the changes nested under its coordinate
+type ChangeGroup struct {
+	Change
+	Children []ChangeGroup `json:"children,omitempty"`
+}
+
+// Related returns the number of changes nested under the group, at any depth
+func (g ChangeGroup) Related() int {
+	count := 0
+	for _, child := range g.Children {
+		count += 1 + child.Related()
+	}
+	return count
+}
+
+// GroupChanges nests changes under the change made to their closest enclosing
+// coordinate, e.g. field changes under the removal of their type. Only breaking and
+// dangerous changes absorb nested changes, and only those at most as severe, so a
+// breaking change never hides behind a lesser one. Top-level groups keep the order
+// of the input.
+func GroupChanges(changes []Change) []ChangeGroup {
+	nodes := make([]*changeNode, len(changes))
+	for i, change := range changes {
+		nodes[i] = &changeNode{index: i, change: change}
+	}
+
+	// Place ancestors before their descendants
+	ordered := make([]*changeNode, len(nodes))
+	copy(ordered, nodes)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return coordinateDepth(ordered[i].change.Path) < coordinateDepth(ordered[j].change.Path)
+	})
+
+	byPath := make(map[string][]*changeNode)
+	var roots []*changeNode
+
+	for _, n := range ordered {
+		parent := findParentGroup(byPath, n.change)
+		if parent != nil {
+			parent.children = append(parent.children, n)
+		} else {
+			roots = append(roots, n)
+		}
+		if n.change.Path != "" {
+			byPath[n.change.Path] = append(byPath[n.change.Path], n)
+		}
+	}
+
+	var convert func(ns []*changeNode) []ChangeGroup
+	convert = func(ns []*changeNode) []ChangeGroup {
+		if len(ns) == 0 {
+			return nil
+		}
+		sort.Slice(ns, func(i, j int) bool {
+			return ns[i].index < ns[j].index
+		})
+		groups := make([]ChangeGroup, 0, len(ns))
+		for _, n := range ns {
+			groups = append(groups, ChangeGroup{
+				Change:   n.change,
+				Children: convert(n.children),
+			})
+		}
+		return groups
+	}
+
+	return convert(roots)
+}
+
+// changeNode is a change being placed in the group tree
+type changeNode struct {
+	index    int
+	change   Change
+	children []*changeNode
+}
+
+// findParentGroup returns the change at the nearest enclosing coordinate that may absorb a change
+func findParentGroup(byPath map[string][]*changeNode, change Change) *changeNode {
+	for parent := parentCoordinate(change.Path); parent != ""; parent = parentCoordinate(parent) {
+		for _, candidate := range byPath[parent] {
+			if candidate.change.Type == ChangeTypeNonBreaking {
+				continue
+			}
+			if changeSeverity(candidate.change.Type) >= changeSeverity(change.Type) {
+				return candidate
+			}
+		}
+	}
+	return nil
+}
+
+// coordinateDepth returns how deeply a coordinate is nested: 0 for types, 1 for fields, 2 for arguments
+func coordinateDepth(path string) int {
+	depth := 0
+	for parent := parentCoordinate(path); parent != ""; parent = parentCoordinate(parent) {
+		depth++
+	}
+	return depth
+}
+
+// changeSeverity ranks change types from least to most severe
+func changeSeverity(changeType ChangeType) int {
+	switch changeType {
+	case ChangeTypeBreaking:
+		return 3
+	case ChangeTypeDangerous:
+		return 2
+	case ChangeTypeNonBreaking:
+		return 1
+	default:
+		return 0
+	}
+}
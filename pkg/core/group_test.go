@@ -0,0 +1,91 @@
+package core_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestGroupChanges(t *testing.T) {
+	breaking := func(path string) core.Change {
+		return core.Change{Type: core.ChangeTypeBreaking, Message: "breaking " + path, Path: path}
+	}
+	dangerous := func(path string) core.Change {
+		return core.Change{Type: core.ChangeTypeDangerous, Message: "dangerous " + path, Path: path}
+	}
+	safe := func(path string) core.Change {
+		return core.Change{Type: core.ChangeTypeNonBreaking, Message: "safe " + path, Path: path}
+	}
+
+	tests := []struct {
+		name    string
+		changes []core.Change
+		// want holds the paths of the top-level groups, and folded the paths nested
+		// under each of them, depth first
+		want    []string
+		folded  map[string][]string
+		related map[string]int
+	}{
+		{
+			name:    "fields and arguments fold under their removed type",
+			changes: []core.Change{breaking("User.name(format:)"), breaking("User.name"), breaking("User"), breaking("Query.user")},
+			want:    []string{"User", "Query.user"},
+			folded:  map[string][]string{"User": {"User.name", "User.name(format:)"}},
+			related: map[string]int{"User": 2, "Query.user": 0},
+		},
+		{
+			name:    "changes skip a missing level to the nearest enclosing change",
+			changes: []core.Change{dangerous("Query"), safe("Query.users(first:)")},
+			want:    []string{"Query"},
+			folded:  map[string][]string{"Query": {"Query.users(first:)"}},
+			related: map[string]int{"Query": 1},
+		},
+		{
+			name:    "non-breaking changes don't absorb nested changes",
+			changes: []core.Change{safe("User"), safe("User.email")},
+			want:    []string{"User", "User.email"},
+		},
+		{
+			name:    "more severe changes aren't hidden under lesser ones",
+			changes: []core.Change{dangerous("Query.users"), breaking("Query.users(first:)"), dangerous("Query.users(after:)")},
+			want:    []string{"Query.users", "Query.users(first:)"},
+			folded:  map[string][]string{"Query.users": {"Query.users(after:)"}},
+		},
+		{
+			name:    "unrelated coordinates keep the input order",
+			changes: []core.Change{safe("B.b"), breaking("A"), safe("C")},
+			want:    []string{"B.b", "A", "C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := core.GroupChanges(tt.changes)
+
+			var got []string
+			for _, group := range groups {
+				got = append(got, group.Path)
+
+				var folded []string
+				var walk func(children []core.ChangeGroup)
+				walk = func(children []core.ChangeGroup) {
+					for _, child := range children {
+						folded = append(folded, child.Path)
+						walk(child.Children)
+					}
+				}
+				walk(group.Children)
+				if !reflect.DeepEqual(folded, tt.folded[group.Path]) {
+					t.Errorf("changes folded under %s = %v, want %v", group.Path, folded, tt.folded[group.Path])
+				}
+				if want, ok := tt.related[group.Path]; ok && group.Related() != want {
+					t.Errorf("%s Related() = %d, want %d", group.Path, group.Related(), want)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("top-level groups = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
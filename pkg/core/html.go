@@ -0,0 +1,279 @@
+package core
+
+import (
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GenerateCoverageHTML generates a static, self-contained HTML coverage report.
+// It returns the content of each page keyed by its path relative to the report
+// directory: index.html renders the schema SDL with the coverage and hit count of
+// each line, and documents/ holds a page per document for usages to link to.
+func GenerateCoverageHTML(schema *Schema, documents []Document, result *CoverageResult) (map[string]string, error) {
+	lines, err := AnnotateSchemaCoverage(schema, result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Name a page for each document, in source order
+	sources := make([]string, 0, len(documents))
+	contents := make(map[string]string, len(documents))
+	for _, doc := range documents {
+		if _, exists := contents[doc.Source]; !exists {
+			sources = append(sources, doc.Source)
+		}
+		contents[doc.Source] = doc.Content
+	}
+	sort.Strings(sources)
+	pages := make(map[string]string, len(sources))
+	for i, source := range sources {
+		pages[source] = fmt.Sprintf("documents/%03d-%s.html", i+1, pageName(source))
+	}
+
+	report := htmlReport{
+		Summary: htmlSummary(result),
+		Types:   htmlTypes(result),
+		Traffic: result.TotalHits > 0 || result.ZeroTraffic != nil,
+	}
+	usedLines := make(map[string]map[int]bool)
+	for number, line := range lines {
+		row := htmlLine{Number: number + 1, Text: line.Text}
+		if line.Coordinate != "" && !strings.Contains(line.Coordinate, ".") {
+			row.Anchor = line.Coordinate
+		}
+		if line.Tracked {
+			row.Class = "uncovered"
+			if line.Covered {
+				row.Class = "covered"
+			}
+		}
+		if line.Hits != nil {
+			row.Hits = fmt.Sprint(*line.Hits)
+		}
+		for _, usage := range result.Usages[line.Coordinate] {
+			link := htmlLink{Label: fmt.Sprintf("%s in %s", usage.Source, usage.Operation), Href: pages[usage.Source]}
+			if usage.Range != nil {
+				link.Label = fmt.Sprintf("%s:%d:%d in %s", usage.Source, usage.Range.Start.Line, usage.Range.Start.Column, usage.Operation)
+				link.Href += fmt.Sprintf("#L%d", usage.Range.Start.Line)
+				if usedLines[usage.Source] == nil {
+					usedLines[usage.Source] = make(map[int]bool)
+				}
+				usedLines[usage.Source][usage.Range.Start.Line] = true
+			}
+			row.Usages = append(row.Usages, link)
+		}
+		row.Arguments = htmlArguments(line, result)
+		report.Lines = append(report.Lines, row)
+	}
+
+	files := make(map[string]string, len(sources)+1)
+	var sb strings.Builder
+	if err := indexTemplate.Execute(&sb, report); err != nil {
+		return nil, fmt.Errorf("failed to render coverage report: %w", err)
+	}
+	files["index.html"] = sb.String()
+
+	for _, source := range sources {
+		page := htmlDocument{Source: source}
+		for number, text := range strings.Split(strings.TrimSuffix(contents[source], "\n"), "\n") {
+			page.Lines = append(page.Lines, htmlLine{Number: number + 1, Text: text})
+			if usedLines[source][number+1] {
+				page.Lines[number].Class = "covered"
+			}
+		}
+		sb.Reset()
+		if err := documentTemplate.Execute(&sb, page); err != nil {
+			return nil, fmt.Errorf("failed to render document %s: %w", source, err)
+		}
+		files[pages[source]] = sb.String()
+	}
+
+	return files, nil
+}
+
+// htmlReport is the data rendered into index.html
+type htmlReport struct {
+	Summary []htmlRatio
+	Types   []htmlRatio
+	Lines   []htmlLine
+	Traffic bool
+}
+
+// htmlRatio is a coverage ratio rendered as a bar
+type htmlRatio struct {
+	Label   string
+	Anchor  string
+	Covered int
+	Total   int
+	Percent float64
+}
+
+// htmlLine is a line of SDL or of a document
+type htmlLine struct {
+	Number    int
+	Text      string
+	Anchor    string
+	Class     string
+	Hits      string
+	Usages    []htmlLink
+	Arguments []htmlArgument
+}
+
+// htmlLink links a usage to the line of the document it's in
+type htmlLink struct {
+	Label string
+	Href  string
+}
+
+// htmlArgument is the coverage of an argument printed inline with its field
+type htmlArgument struct {
+	Name    string
+	Covered bool
+}
+
+// htmlDocument is the data rendered into a document page
+type htmlDocument struct {
+	Source string
+	Lines  []htmlLine
+}
+
+// htmlSummary returns the overall ratios of each coverage dimension that was measured
+func htmlSummary(result *CoverageResult) []htmlRatio {
+	ratios := []htmlRatio{
+		{Label: "Types", Covered: result.TypesCovered, Total: result.TotalTypes},
+		{Label: "Fields", Covered: result.FieldsCovered, Total: result.TotalFields},
+		{Label: "Arguments", Covered: result.ArgumentsCovered, Total: result.TotalArguments},
+		{Label: "Enum Values", Covered: result.EnumValuesCovered, Total: result.TotalEnumValues},
+		{Label: "Input Fields", Covered: result.InputFieldsCovered, Total: result.TotalInputFields},
+	}
+	summary := []htmlRatio{{Label: "Overall", Percent: result.Coverage * 100}}
+	for _, ratio := range ratios {
+		if ratio.Total > 0 {
+			ratio.Percent = coverageRatio(ratio.Covered, ratio.Total) * 100
+			summary = append(summary, ratio)
+		}
+	}
+	return summary
+}
+
+// htmlTypes returns the coverage ratio of each type's members, sorted by type name.
+// Types without members, such as scalars, count as fully covered or not at all.
+func htmlTypes(result *CoverageResult) []htmlRatio {
+	names := make([]string, 0, len(result.Details))
+	for name := range result.Details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	types := make([]htmlRatio, 0, len(names))
+	for _, name := range names {
+		typeCoverage := result.Details[name]
+		ratio := htmlRatio{Label: name, Anchor: name}
+		ratio.Covered, ratio.Total = typeMemberCounts(typeCoverage)
+		if ratio.Total == 0 {
+			ratio.Total = 1
+			if typeCoverage.Covered {
+				ratio.Covered = 1
+			}
+		}
+		ratio.Percent = coverageRatio(ratio.Covered, ratio.Total) * 100
+		types = append(types, ratio)
+	}
+	return types
+}
+
+// htmlArguments returns the coverage of the arguments printed on a field's line, sorted
+func htmlArguments(line CoverageLine, result *CoverageResult) []htmlArgument {
+	var arguments []htmlArgument
+	for name, covered := range inlineArguments(line, result) {
+		arguments = append(arguments, htmlArgument{Name: name, Covered: covered})
+	}
+	sort.Slice(arguments, func(i, j int) bool {
+		return arguments[i].Name < arguments[j].Name
+	})
+	return arguments
+}
+
+var unsafePageChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// pageName derives a readable file name from a document source
+func pageName(source string) string {
+	name := strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	return strings.Trim(unsafePageChars.ReplaceAllString(name, "-"), "-")
+}
+
+const htmlStyle = `
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+h1, h2 { font-weight: 600; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+table.ratios td { padding: 2px 8px; white-space: nowrap; }
+.bar { display: inline-block; width: 200px; height: 10px; background: #ffd7d5; border-radius: 2px; vertical-align: middle; }
+.bar span { display: block; height: 100%; background: #2da44e; border-radius: 2px; }
+table.source { border-collapse: collapse; font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 13px; }
+table.source td { padding: 0 8px; vertical-align: top; }
+td.number, td.hits { color: #8c959f; text-align: right; user-select: none; }
+td.code { white-space: pre; }
+tr.covered td.code { background: #dafbe1; }
+tr.uncovered td.code { background: #ffebe9; }
+.argument.covered { color: #1a7f37; }
+.argument.uncovered { color: #cf222e; }
+details summary { cursor: pointer; color: #0969da; }
+details ul { margin: 0; padding-left: 1.5em; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; }
+`
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>GraphQL Schema Coverage</title>
+<style>` + htmlStyle + `</style>
+</head>
+<body>
+<h1>GraphQL Schema Coverage</h1>
+<table class="ratios">
+{{- range .Summary}}
+<tr><td>{{.Label}}</td><td><span class="bar"><span style="width: {{printf "%.2f" .Percent}}%"></span></span></td><td>{{printf "%.2f" .Percent}}%</td><td>{{if .Total}}{{.Covered}}/{{.Total}}{{end}}</td></tr>
+{{- end}}
+</table>
+<h2>Types</h2>
+<table class="ratios">
+{{- range .Types}}
+<tr><td><a href="#{{.Anchor}}">{{.Label}}</a></td><td><span class="bar"><span style="width: {{printf "%.2f" .Percent}}%"></span></span></td><td>{{printf "%.2f" .Percent}}%</td><td>{{.Covered}}/{{.Total}}</td></tr>
+{{- end}}
+</table>
+<h2>Schema</h2>
+<table class="source">
+{{- range .Lines}}
+<tr{{if .Anchor}} id="{{.Anchor}}"{{end}}{{if .Class}} class="{{.Class}}"{{end}}><td class="number">{{.Number}}</td>{{if $.Traffic}}<td class="hits">{{.Hits}}</td>{{end}}<td class="code">{{.Text}}</td><td>
+{{- range .Arguments}}<span class="argument {{if .Covered}}covered{{else}}uncovered{{end}}">{{.Name}}:</span> {{end}}
+{{- if .Usages}}<details><summary>{{len .Usages}} usages</summary><ul>{{range .Usages}}<li><a href="{{.Href}}">{{.Label}}</a></li>{{end}}</ul></details>{{end -}}
+</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+var documentTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Source}}</title>
+<style>` + htmlStyle + `</style>
+</head>
+<body>
+<p><a href="../index.html">← Schema coverage</a></p>
+<h1>{{.Source}}</h1>
+<table class="source">
+{{- range .Lines}}
+<tr id="L{{.Number}}"{{if .Class}} class="{{.Class}}"{{end}}><td class="number">{{.Number}}</td><td class="code">{{.Text}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
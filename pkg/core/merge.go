@@ -0,0 +1,665 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// MergeConflict represents a schema coordinate changed incompatibly by both sides of a merge
+type MergeConflict struct {
+	Path    string   `json:"path"`
+	Message string   `json:"message"`
+	Ours    []Change `json:"ours,omitempty"`
+	Theirs  []Change `json:"theirs,omitempty"`
+}
+
+// MergeResult represents the result of a three-way schema merge
+type MergeResult struct {
+	Ours      []Change        `json:"ours"`
+	Theirs    []Change        `json:"theirs"`
+	Conflicts []MergeConflict `json:"conflicts,omitempty"`
+	SDL       string          `json:"sdl,omitempty"`
+}
+
+// MergeSchemas performs a three-way merge of two schemas that both evolved from base.
+// The merged SDL is only produced when no conflicts are found.
+func MergeSchemas(base, ours, theirs *Schema, options *DiffOptions) (*MergeResult, error) {
+	if base == nil || ours == nil || theirs == nil {
+		return nil, fmt.Errorf("base, ours and theirs schemas must be provided")
+	}
+	if options == nil {
+		options = &DiffOptions{}
+	}
+
+	ourChanges, err := DiffSchemas(base, ours, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare base with ours: %w", err)
+	}
+
+	theirChanges, err := DiffSchemas(base, theirs, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare base with theirs: %w", err)
+	}
+
+	result := &MergeResult{
+		Ours:   ourChanges,
+		Theirs: theirChanges,
+	}
+
+	// Semantic conflicts come from the diffs of each side against base
+	result.Conflicts = detectMergeConflicts(ours, theirs, ourChanges, theirChanges)
+
+	// Anything the diff engine doesn't model yet is still caught while merging the SDL
+	sdl, conflicts, err := mergeSDL(base, ours, theirs, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		if overlapsConflict(result.Conflicts, conflict.Path) {
+			continue
+		}
+		conflict.Ours = changesWithin(ourChanges, conflict.Path)
+		conflict.Theirs = changesWithin(theirChanges, conflict.Path)
+		result.Conflicts = append(result.Conflicts, conflict)
+	}
+	if len(result.Conflicts) == 0 {
+		result.SDL = sdl
+	}
+
+	return result, nil
+}
+
+// detectMergeConflicts finds coordinates both sides changed differently, and
+// coordinates one side changed inside something the other side removed
+func detectMergeConflicts(ours, theirs *Schema, ourChanges, theirChanges []Change) []MergeConflict {
+	var conflicts []MergeConflict
+
+	ourPaths := groupChangesByPath(ourChanges)
+	theirPaths := groupChangesByPath(theirChanges)
+
+	for _, path := range sortedChangePaths(ourPaths) {
+		theirs, exists := theirPaths[path]
+		if !exists || sameChanges(ourPaths[path], theirs) {
+			continue
+		}
+		conflicts = append(conflicts, MergeConflict{
+			Path:    path,
+			Message: fmt.Sprintf("'%s' was changed differently on both sides", path),
+			Ours:    ourPaths[path],
+			Theirs:  theirs,
+		})
+	}
+
+	conflicts = append(conflicts, detectRemovalConflicts(ours, ourPaths, theirPaths, "ours", "theirs")...)
+	conflicts = append(conflicts, detectRemovalConflicts(theirs, theirPaths, ourPaths, "theirs", "ours")...)
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Path < conflicts[j].Path
+	})
+
+	return conflicts
+}
+
+// detectRemovalConflicts finds changes on one side nested under a coordinate the other side removed
+func detectRemovalConflicts(removing *Schema, removingPaths, changingPaths map[string][]Change, removingSide, changingSide string) []MergeConflict {
+	var conflicts []MergeConflict
+
+	for _, removed := range sortedChangePaths(removingPaths) {
+		if hasCoordinate(removing.Schema, removed) {
+			continue
+		}
+		for _, changed := range sortedChangePaths(changingPaths) {
+			if !isCoordinateAncestor(removed, changed) {
+				continue
+			}
+
+			conflict := MergeConflict{
+				Path:    changed,
+				Message: fmt.Sprintf("'%s' was removed in %s but '%s' was changed in %s", removed, removingSide, changed, changingSide),
+			}
+			if removingSide == "ours" {
+				conflict.Ours = removingPaths[removed]
+				conflict.Theirs = changingPaths[changed]
+			} else {
+				conflict.Ours = changingPaths[changed]
+				conflict.Theirs = removingPaths[removed]
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts
+}
+
+// groupChangesByPath groups changes by the coordinate they apply to
+func groupChangesByPath(changes []Change) map[string][]Change {
+	grouped := make(map[string][]Change)
+	for _, change := range changes {
+		grouped[change.Path] = append(grouped[change.Path], change)
+	}
+	return grouped
+}
+
+// sortedChangePaths returns the paths of grouped changes in a stable order
+func sortedChangePaths(grouped map[string][]Change) []string {
+	paths := make([]string, 0, len(grouped))
+	for path := range grouped {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// sameChanges reports whether both sides made exactly the same changes to a coordinate
+func sameChanges(a, b []Change) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	key := func(changes []Change) []string {
+		keys := make([]string, 0, len(changes))
+		for _, change := range changes {
+			keys = append(keys, fmt.Sprintf("%s|%s|%v", change.Type, change.Message, change.Meta))
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	aKeys, bKeys := key(a), key(b)
+	for i := range aKeys {
+		if aKeys[i] != bKeys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapsConflict reports whether a path is already covered by a reported conflict
+func overlapsConflict(conflicts []MergeConflict, path string) bool {
+	for _, conflict := range conflicts {
+		if conflict.Path == path || isCoordinateAncestor(conflict.Path, path) || isCoordinateAncestor(path, conflict.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// changesWithin returns the changes at a coordinate or nested inside it
+func changesWithin(changes []Change, path string) []Change {
+	var within []Change
+	for _, change := range changes {
+		if change.Path == path || isCoordinateAncestor(path, change.Path) {
+			within = append(within, change)
+		}
+	}
+	return within
+}
+
+// mergeSDL merges the SDL of both sides definition by definition, and member by member
+// where both sides changed the same definition. Differences the options ignore never
+// conflict, and resolve to our side.
+func mergeSDL(base, ours, theirs *Schema, options *DiffOptions) (string, []MergeConflict, error) {
+	baseDoc, err := parseSchemaSDL(base)
+	if err != nil {
+		return "", nil, fmt.Errorf("base: %w", err)
+	}
+	ourDoc, err := parseSchemaSDL(ours)
+	if err != nil {
+		return "", nil, fmt.Errorf("ours: %w", err)
+	}
+	theirDoc, err := parseSchemaSDL(theirs)
+	if err != nil {
+		return "", nil, fmt.Errorf("theirs: %w", err)
+	}
+
+	baseKeys, baseDefs := indexDefinitions(baseDoc)
+	ourKeys, ourDefs := indexDefinitions(ourDoc)
+	theirKeys, theirDefs := indexDefinitions(theirDoc)
+
+	var merged []ast.Node
+	var conflicts []MergeConflict
+
+	for _, key := range mergeKeys(baseKeys, ourKeys, theirKeys) {
+		def, defConflicts := mergeDefinition(key, baseDefs[key], ourDefs[key], theirDefs[key], options)
+		conflicts = append(conflicts, defConflicts...)
+		if def != nil {
+			merged = append(merged, def)
+		}
+	}
+
+	if len(merged) == 0 {
+		return "", conflicts, nil
+	}
+	return printSDL(merged), conflicts, nil
+}
+
+// indexDefinitions indexes top-level definitions by a key unique within the document
+func indexDefinitions(doc *ast.Document) ([]string, map[string]ast.Node) {
+	var keys []string
+	defs := make(map[string]ast.Node)
+	extensions := make(map[string]int)
+
+	for _, def := range doc.Definitions {
+		key := definitionName(def)
+		if key == "" {
+			continue
+		}
+		if _, ok := def.(*ast.TypeExtensionDefinition); ok {
+			extensions[key]++
+			key = fmt.Sprintf("extend %s#%d", key, extensions[key])
+		}
+		if _, exists := defs[key]; !exists {
+			keys = append(keys, key)
+		}
+		defs[key] = def
+	}
+
+	return keys, defs
+}
+
+// mergeKeys returns base keys followed by keys added in ours and then theirs
+func mergeKeys(lists ...[]string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, key := range list {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// mergeDefinition three-way merges a single definition
+func mergeDefinition(key string, base, ours, theirs ast.Node, options *DiffOptions) (ast.Node, []MergeConflict) {
+	if node, resolved := pickSide(printOptional(base), printOptional(ours), printOptional(theirs), ours, theirs); resolved {
+		return node, nil
+	}
+	normalize := func(node ast.Node) string {
+		return printOptional(normalizeDefinition(node, options))
+	}
+	if node, resolved := pickSide(normalize(base), normalize(ours), normalize(theirs), ours, theirs); resolved {
+		return node, nil
+	}
+
+	if ours != nil && theirs != nil {
+		if merged, conflicts, ok := mergeDefinitionMembers(key, base, ours, theirs, options); ok {
+			return merged, conflicts
+		}
+	}
+
+	return nil, []MergeConflict{{
+		Path:    key,
+		Message: fmt.Sprintf("definition of '%s' was changed differently on both sides", key),
+	}}
+}
+
+// pickSide resolves a three-way merge of printed nodes when at most one side diverged from base
+func pickSide(base, ours, theirs string, ourNode, theirNode ast.Node) (ast.Node, bool) {
+	switch {
+	case ours == theirs, theirs == base:
+		return ourNode, true
+	case ours == base:
+		return theirNode, true
+	default:
+		return nil, false
+	}
+}
+
+// printOptional prints a definition, or returns an empty string when it is absent
+func printOptional(node ast.Node) string {
+	if node == nil {
+		return ""
+	}
+	return printDefinition(node)
+}
+
+// mergeDefinitionMembers merges the fields, values or members of a definition both sides changed.
+// It returns false when the definitions can't be merged member by member.
+func mergeDefinitionMembers(path string, base, ours, theirs ast.Node, options *DiffOptions) (ast.Node, []MergeConflict, bool) {
+	switch o := ours.(type) {
+	case *ast.ObjectDefinition:
+		t, ok := theirs.(*ast.ObjectDefinition)
+		b, baseOk := base.(*ast.ObjectDefinition)
+		if !ok || (base != nil && !baseOk) {
+			return nil, nil, false
+		}
+		if b == nil {
+			b = &ast.ObjectDefinition{}
+		}
+		header, ok := mergeHeader(
+			&ast.ObjectDefinition{Kind: b.Kind, Name: b.Name, Description: b.Description, Interfaces: b.Interfaces, Directives: b.Directives},
+			&ast.ObjectDefinition{Kind: o.Kind, Name: o.Name, Description: o.Description, Interfaces: o.Interfaces, Directives: o.Directives},
+			&ast.ObjectDefinition{Kind: t.Kind, Name: t.Name, Description: t.Description, Interfaces: t.Interfaces, Directives: t.Directives},
+			base != nil,
+			options,
+		)
+		if !ok {
+			return nil, nil, false
+		}
+		merged := *header.(*ast.ObjectDefinition)
+		fields, conflicts := mergeMembers(path, b.Fields, o.Fields, t.Fields, fieldDefinitionName, printField, func(field *ast.FieldDefinition) string {
+			return printField(normalizeField(field, options))
+		})
+		merged.Fields = fields
+		return &merged, conflicts, true
+
+	case *ast.InterfaceDefinition:
+		t, ok := theirs.(*ast.InterfaceDefinition)
+		b, baseOk := base.(*ast.InterfaceDefinition)
+		if !ok || (base != nil && !baseOk) {
+			return nil, nil, false
+		}
+		if b == nil {
+			b = &ast.InterfaceDefinition{}
+		}
+		header, ok := mergeHeader(
+			&ast.InterfaceDefinition{Kind: b.Kind, Name: b.Name, Description: b.Description, Directives: b.Directives},
+			&ast.InterfaceDefinition{Kind: o.Kind, Name: o.Name, Description: o.Description, Directives: o.Directives},
+			&ast.InterfaceDefinition{Kind: t.Kind, Name: t.Name, Description: t.Description, Directives: t.Directives},
+			base != nil,
+			options,
+		)
+		if !ok {
+			return nil, nil, false
+		}
+		merged := *header.(*ast.InterfaceDefinition)
+		fields, conflicts := mergeMembers(path, b.Fields, o.Fields, t.Fields, fieldDefinitionName, printField, func(field *ast.FieldDefinition) string {
+			return printField(normalizeField(field, options))
+		})
+		merged.Fields = fields
+		return &merged, conflicts, true
+
+	case *ast.InputObjectDefinition:
+		t, ok := theirs.(*ast.InputObjectDefinition)
+		b, baseOk := base.(*ast.InputObjectDefinition)
+		if !ok || (base != nil && !baseOk) {
+			return nil, nil, false
+		}
+		if b == nil {
+			b = &ast.InputObjectDefinition{}
+		}
+		header, ok := mergeHeader(
+			&ast.InputObjectDefinition{Kind: b.Kind, Name: b.Name, Description: b.Description, Directives: b.Directives},
+			&ast.InputObjectDefinition{Kind: o.Kind, Name: o.Name, Description: o.Description, Directives: o.Directives},
+			&ast.InputObjectDefinition{Kind: t.Kind, Name: t.Name, Description: t.Description, Directives: t.Directives},
+			base != nil,
+			options,
+		)
+		if !ok {
+			return nil, nil, false
+		}
+		merged := *header.(*ast.InputObjectDefinition)
+		fields, conflicts := mergeMembers(path, b.Fields, o.Fields, t.Fields, inputValueName, func(v *ast.InputValueDefinition) string {
+			return printInputValue(v, "  ")
+		}, func(v *ast.InputValueDefinition) string {
+			return printInputValue(normalizeInputValue(v, options), "  ")
+		})
+		merged.Fields = fields
+		return &merged, conflicts, true
+
+	case *ast.EnumDefinition:
+		t, ok := theirs.(*ast.EnumDefinition)
+		b, baseOk := base.(*ast.EnumDefinition)
+		if !ok || (base != nil && !baseOk) {
+			return nil, nil, false
+		}
+		if b == nil {
+			b = &ast.EnumDefinition{}
+		}
+		header, ok := mergeHeader(
+			&ast.EnumDefinition{Kind: b.Kind, Name: b.Name, Description: b.Description, Directives: b.Directives},
+			&ast.EnumDefinition{Kind: o.Kind, Name: o.Name, Description: o.Description, Directives: o.Directives},
+			&ast.EnumDefinition{Kind: t.Kind, Name: t.Name, Description: t.Description, Directives: t.Directives},
+			base != nil,
+			options,
+		)
+		if !ok {
+			return nil, nil, false
+		}
+		merged := *header.(*ast.EnumDefinition)
+		values, conflicts := mergeMembers(path, b.Values, o.Values, t.Values, enumValueName, printEnumValue, func(value *ast.EnumValueDefinition) string {
+			return printEnumValue(normalizeEnumValue(value, options))
+		})
+		merged.Values = values
+		return &merged, conflicts, true
+
+	case *ast.UnionDefinition:
+		t, ok := theirs.(*ast.UnionDefinition)
+		b, baseOk := base.(*ast.UnionDefinition)
+		if !ok || (base != nil && !baseOk) {
+			return nil, nil, false
+		}
+		if b == nil {
+			b = &ast.UnionDefinition{}
+		}
+		header, ok := mergeHeader(
+			&ast.UnionDefinition{Kind: b.Kind, Name: b.Name, Description: b.Description, Directives: b.Directives},
+			&ast.UnionDefinition{Kind: o.Kind, Name: o.Name, Description: o.Description, Directives: o.Directives},
+			&ast.UnionDefinition{Kind: t.Kind, Name: t.Name, Description: t.Description, Directives: t.Directives},
+			base != nil,
+			options,
+		)
+		if !ok {
+			return nil, nil, false
+		}
+		merged := *header.(*ast.UnionDefinition)
+		members, conflicts := mergeMembers(path, b.Types, o.Types, t.Types, namedTypeName, namedTypeName, namedTypeName)
+		merged.Types = members
+		return &merged, conflicts, true
+	}
+
+	return nil, nil, false
+}
+
+// mergeHeader three-way merges a definition stripped of its members
+func mergeHeader(base, ours, theirs ast.Node, hasBase bool, options *DiffOptions) (ast.Node, bool) {
+	for _, print := range []func(ast.Node) string{
+		printDefinition,
+		func(node ast.Node) string { return printDefinition(normalizeDefinition(node, options)) },
+	} {
+		basePrinted := ""
+		if hasBase {
+			basePrinted = print(base)
+		}
+		if node, resolved := pickSide(basePrinted, print(ours), print(theirs), ours, theirs); resolved {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// mergeMembers three-way merges a list of named members, keeping base order and
+// appending members added by ours and then theirs. Members that only diverged in
+// ways normalized away by printNormalized resolve to ours.
+func mergeMembers[T any](path string, base, ours, theirs []T, name func(T) string, print, printNormalized func(T) string) ([]T, []MergeConflict) {
+	index := func(members []T) ([]string, map[string]T) {
+		var keys []string
+		byName := make(map[string]T)
+		for _, member := range members {
+			keys = append(keys, name(member))
+			byName[name(member)] = member
+		}
+		return keys, byName
+	}
+
+	baseKeys, baseByName := index(base)
+	ourKeys, ourByName := index(ours)
+	theirKeys, theirByName := index(theirs)
+
+	printed := func(byName map[string]T, key string, print func(T) string) string {
+		if member, exists := byName[key]; exists {
+			return print(member)
+		}
+		return ""
+	}
+
+	var merged []T
+	var conflicts []MergeConflict
+
+	for _, key := range mergeKeys(baseKeys, ourKeys, theirKeys) {
+		b, o, t := printed(baseByName, key, print), printed(ourByName, key, print), printed(theirByName, key, print)
+		nb, no, nt := printed(baseByName, key, printNormalized), printed(ourByName, key, printNormalized), printed(theirByName, key, printNormalized)
+
+		var source map[string]T
+		switch {
+		case o == t, t == b:
+			source = ourByName
+		case o == b:
+			source = theirByName
+		case no == nt, nt == nb:
+			source = ourByName
+		case no == nb:
+			source = theirByName
+		default:
+			memberPath := path + "." + key
+			conflicts = append(conflicts, MergeConflict{
+				Path:    memberPath,
+				Message: fmt.Sprintf("'%s' was changed differently on both sides", memberPath),
+			})
+			continue
+		}
+
+		if member, exists := source[key]; exists {
+			merged = append(merged, member)
+		}
+	}
+
+	return merged, conflicts
+}
+
+func fieldDefinitionName(field *ast.FieldDefinition) string {
+	return field.Name.Value
+}
+
+func inputValueName(value *ast.InputValueDefinition) string {
+	return value.Name.Value
+}
+
+func enumValueName(value *ast.EnumValueDefinition) string {
+	return value.Name.Value
+}
+
+func namedTypeName(named *ast.Named) string {
+	return named.Name.Value
+}
+
+// normalizeDefinition returns a copy of a definition without the descriptions and
+// directives the options ignore, for comparison only
+func normalizeDefinition(def ast.Node, options *DiffOptions) ast.Node {
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		normalized := *def
+		normalized.Description = normalizeDescriptionValue(def.Description, options)
+		normalized.Directives = normalizeDirectives(def.Directives, options)
+		normalized.Fields = make([]*ast.FieldDefinition, 0, len(def.Fields))
+		for _, field := range def.Fields {
+			normalized.Fields = append(normalized.Fields, normalizeField(field, options))
+		}
+		return &normalized
+	case *ast.TypeExtensionDefinition:
+		normalized := *def
+		if def.Definition != nil {
+			normalized.Definition = normalizeDefinition(def.Definition, options).(*ast.ObjectDefinition)
+		}
+		return &normalized
+	case *ast.InterfaceDefinition:
+		normalized := *def
+		normalized.Description = normalizeDescriptionValue(def.Description, options)
+		normalized.Directives = normalizeDirectives(def.Directives, options)
+		normalized.Fields = make([]*ast.FieldDefinition, 0, len(def.Fields))
+		for _, field := range def.Fields {
+			normalized.Fields = append(normalized.Fields, normalizeField(field, options))
+		}
+		return &normalized
+	case *ast.UnionDefinition:
+		normalized := *def
+		normalized.Description = normalizeDescriptionValue(def.Description, options)
+		normalized.Directives = normalizeDirectives(def.Directives, options)
+		return &normalized
+	case *ast.EnumDefinition:
+		normalized := *def
+		normalized.Description = normalizeDescriptionValue(def.Description, options)
+		normalized.Directives = normalizeDirectives(def.Directives, options)
+		normalized.Values = make([]*ast.EnumValueDefinition, 0, len(def.Values))
+		for _, value := range def.Values {
+			normalized.Values = append(normalized.Values, normalizeEnumValue(value, options))
+		}
+		return &normalized
+	case *ast.InputObjectDefinition:
+		normalized := *def
+		normalized.Description = normalizeDescriptionValue(def.Description, options)
+		normalized.Directives = normalizeDirectives(def.Directives, options)
+		normalized.Fields = make([]*ast.InputValueDefinition, 0, len(def.Fields))
+		for _, field := range def.Fields {
+			normalized.Fields = append(normalized.Fields, normalizeInputValue(field, options))
+		}
+		return &normalized
+	case *ast.ScalarDefinition:
+		normalized := *def
+		normalized.Description = normalizeDescriptionValue(def.Description, options)
+		normalized.Directives = normalizeDirectives(def.Directives, options)
+		return &normalized
+	case *ast.DirectiveDefinition:
+		if options.IgnoreDirectives {
+			// Directive definitions are ignored altogether, like in DiffSchemas
+			return &ast.DirectiveDefinition{Kind: def.Kind, Name: def.Name}
+		}
+		normalized := *def
+		normalized.Description = normalizeDescriptionValue(def.Description, options)
+		return &normalized
+	}
+	return def
+}
+
+// normalizeField returns a copy of a field definition and its arguments without
+// the descriptions and directives the options ignore
+func normalizeField(field *ast.FieldDefinition, options *DiffOptions) *ast.FieldDefinition {
+	normalized := *field
+	normalized.Description = normalizeDescriptionValue(field.Description, options)
+	normalized.Directives = normalizeDirectives(field.Directives, options)
+	normalized.Arguments = make([]*ast.InputValueDefinition, 0, len(field.Arguments))
+	for _, arg := range field.Arguments {
+		normalized.Arguments = append(normalized.Arguments, normalizeInputValue(arg, options))
+	}
+	return &normalized
+}
+
+// normalizeInputValue returns a copy of an argument or input field without the
+// description and directives the options ignore
+func normalizeInputValue(value *ast.InputValueDefinition, options *DiffOptions) *ast.InputValueDefinition {
+	normalized := *value
+	normalized.Description = normalizeDescriptionValue(value.Description, options)
+	normalized.Directives = normalizeDirectives(value.Directives, options)
+	return &normalized
+}
+
+// normalizeEnumValue returns a copy of an enum value without the description and
+// directives the options ignore
+func normalizeEnumValue(value *ast.EnumValueDefinition, options *DiffOptions) *ast.EnumValueDefinition {
+	normalized := *value
+	normalized.Description = normalizeDescriptionValue(value.Description, options)
+	normalized.Directives = normalizeDirectives(value.Directives, options)
+	return &normalized
+}
+
+// normalizeDescriptionValue drops an ignored description, or normalizes its text
+// the way descriptionsEqual compares it
+func normalizeDescriptionValue(desc *ast.StringValue, options *DiffOptions) *ast.StringValue {
+	if desc == nil || options.IgnoreDescriptions {
+		return nil
+	}
+	return &ast.StringValue{Kind: desc.Kind, Value: normalizeDescription(desc.Value, options)}
+}
+
+// normalizeDirectives drops directive usages when the options ignore them
+func normalizeDirectives(directives []*ast.Directive, options *DiffOptions) []*ast.Directive {
+	if options.IgnoreDirectives {
+		return nil
+	}
+	return directives
+}
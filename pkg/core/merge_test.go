@@ -0,0 +1,190 @@
+package core_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestMergeSchemas(t *testing.T) {
+	base := `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String }
+enum Role { ADMIN }
+`
+
+	tests := []struct {
+		name          string
+		ours          string
+		theirs        string
+		options       *core.DiffOptions
+		wantConflicts []string
+		contains      []string
+	}{
+		{
+			name: "changes to different coordinates merge",
+			ours: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String email: String }
+enum Role { ADMIN }
+`,
+			theirs: `
+type Query { user(id: ID!): User users: [User] }
+type User { id: ID! name: String }
+enum Role { ADMIN USER }
+`,
+			contains: []string{"email: String", "users: [User]", "USER"},
+		},
+		{
+			name: "identical changes on both sides merge",
+			ours: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String! }
+enum Role { ADMIN USER }
+`,
+			theirs: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String! }
+enum Role { ADMIN USER }
+`,
+			contains: []string{"name: String!", "USER"},
+		},
+		{
+			name: "same coordinate changed differently conflicts",
+			ours: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String! }
+enum Role { ADMIN }
+`,
+			theirs: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: Int }
+enum Role { ADMIN }
+`,
+			wantConflicts: []string{"User.name"},
+		},
+		{
+			name: "same field added with different types conflicts",
+			ours: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String email: String }
+enum Role { ADMIN }
+`,
+			theirs: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String email: [String] }
+enum Role { ADMIN }
+`,
+			wantConflicts: []string{"User.email"},
+		},
+		{
+			name: "change inside a coordinate removed on the other side conflicts",
+			ours: `
+type Query { user(id: ID!): User }
+type User { id: ID! }
+enum Role { ADMIN }
+`,
+			theirs: `
+type Query { user(id: ID!): User }
+type User { id: ID! name(format: String): String }
+enum Role { ADMIN }
+`,
+			wantConflicts: []string{"User.name(format:)"},
+		},
+		{
+			name: "enum value added to an enum removed on the other side conflicts",
+			ours: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String }
+`,
+			theirs: `
+type Query { user(id: ID!): User }
+type User { id: ID! name: String }
+enum Role { ADMIN USER }
+`,
+			wantConflicts: []string{"Role"},
+		},
+		{
+			name: "description edits on both sides conflict",
+			ours: `
+"A person"
+type User { id: ID! name: String email: String }
+type Query { user(id: ID!): User }
+enum Role { ADMIN }
+`,
+			theirs: `
+"An account"
+type User { id: ID! name: String age: Int }
+type Query { user(id: ID!): User }
+enum Role { ADMIN }
+`,
+			wantConflicts: []string{"User"},
+		},
+		{
+			name: "description edits are ignored with IgnoreDescriptions",
+			ours: `
+"A person"
+type User { id: ID! name: String email: String }
+type Query { user(id: ID!): User }
+enum Role { ADMIN }
+`,
+			theirs: `
+"An account"
+type User { id: ID! name: String age: Int }
+type Query { user(id: ID!): User }
+enum Role { ADMIN }
+`,
+			options:  &core.DiffOptions{IgnoreDescriptions: true},
+			contains: []string{"\"A person\"\ntype User", "email: String", "age: Int"},
+		},
+		{
+			name: "directive edits are ignored with IgnoreDirectives",
+			ours: `
+directive @auth(role: String) on FIELD_DEFINITION
+type Query { user(id: ID!): User }
+type User { id: ID! name: String @auth(role: "admin") email: String }
+enum Role { ADMIN }
+`,
+			theirs: `
+directive @auth(role: String) on FIELD_DEFINITION
+type Query { user(id: ID!): User }
+type User { id: ID! name: String @auth(role: "owner") }
+enum Role { ADMIN USER }
+`,
+			options:  &core.DiffOptions{IgnoreDirectives: true},
+			contains: []string{`name: String @auth(role: "admin")`, "email: String", "USER"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := core.MergeSchemas(mustLoadSchema(t, base), mustLoadSchema(t, tt.ours), mustLoadSchema(t, tt.theirs), tt.options)
+			if err != nil {
+				t.Fatalf("MergeSchemas() error = %v", err)
+			}
+
+			var paths []string
+			for _, conflict := range result.Conflicts {
+				paths = append(paths, conflict.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantConflicts) {
+				t.Errorf("conflicts = %v, want %v", paths, tt.wantConflicts)
+			}
+
+			if len(tt.wantConflicts) > 0 {
+				if result.SDL != "" {
+					t.Errorf("merged SDL produced despite conflicts:\n%s", result.SDL)
+				}
+				return
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(result.SDL, want) {
+					t.Errorf("merged SDL is missing %q:\n%s", want, result.SDL)
+				}
+			}
+			mustLoadSchema(t, result.SDL)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// operationLocation is a named operation and the document defining it
+type operationLocation struct {
+	source     string
+	definition *ast.OperationDefinition
+}
+
+// describe formats the location of an operation as source:line:column
+func (location operationLocation) describe() string {
+	if r := nodeRange(location.definition.Name); r != nil {
+		return fmt.Sprintf("%s:%d:%d", location.source, r.Start.Line, r.Start.Column)
+	}
+	return location.source
+}
+
+// namedOperations returns the named operations of the documents that parsed, in document order
+func namedOperations(documents []parsedDocument) []operationLocation {
+	var operations []operationLocation
+	for _, parsed := range documents {
+		if parsed.ast == nil {
+			continue
+		}
+		for _, def := range parsed.ast.Definitions {
+			if opDef, ok := def.(*ast.OperationDefinition); ok && opDef.Name != nil {
+				operations = append(operations, operationLocation{source: parsed.doc.Source, definition: opDef})
+			}
+		}
+	}
+	return operations
+}
+
+// duplicateOperationDiagnostics reports operation names defined more than once
+// across a document set, at each definition along with the other locations
+func duplicateOperationDiagnostics(operations []operationLocation) map[string][]Diagnostic {
+	bySource := make(map[string][]Diagnostic)
+
+	byName := make(map[string][]operationLocation)
+	var names []string
+	for _, operation := range operations {
+		name := operation.definition.Name.Value
+		if _, exists := byName[name]; !exists {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], operation)
+	}
+
+	for _, name := range names {
+		locations := byName[name]
+		if !spansSources(locations) {
+			continue // Duplicates within a document are reported by the specification rules
+		}
+
+		for i, location := range locations {
+			others := otherLocations(locations, i)
+			bySource[location.source] = append(bySource[location.source], Diagnostic{
+				RuleID:     "unique-operation-names",
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("Operation '%s' is also defined at %s", name, strings.Join(others, ", ")),
+				Source:     location.source,
+				Operation:  name,
+				Range:      nodeRange(location.definition.Name),
+				Suggestion: "Rename one of the operations; persisted queries and metrics are keyed on operation names",
+			})
+		}
+	}
+
+	return bySource
+}
+
+// identicalOperationDiagnostics reports differently named operations that select
+// exactly the same thing, once fragments are expanded
+func identicalOperationDiagnostics(operations []operationLocation, fragments *fragmentIndex, documents []parsedDocument) map[string][]Diagnostic {
+	bySource := make(map[string][]Diagnostic)
+
+	resolvers := make(map[string]map[string]*ast.FragmentDefinition, len(documents))
+	for _, parsed := range documents {
+		if parsed.ast != nil {
+			resolvers[parsed.doc.Source] = fragments.resolver(parsed.ast)
+		}
+	}
+
+	bySignature := make(map[string][]operationLocation)
+	var signatures []string
+	for _, operation := range operations {
+		signature := operationSignature(operation.definition, resolvers[operation.source])
+		if _, exists := bySignature[signature]; !exists {
+			signatures = append(signatures, signature)
+		}
+		bySignature[signature] = append(bySignature[signature], operation)
+	}
+
+	for _, signature := range signatures {
+		locations := bySignature[signature]
+		names := make(map[string]bool)
+		for _, location := range locations {
+			names[location.definition.Name.Value] = true
+		}
+		if len(names) < 2 {
+			continue // Same name and shape is reported as a duplicate name
+		}
+
+		for i, location := range locations {
+			name := location.definition.Name.Value
+			var others []string
+			for j, other := range locations {
+				if j != i && other.definition.Name.Value != name {
+					others = append(others, fmt.Sprintf("'%s' (%s)", other.definition.Name.Value, other.describe()))
+				}
+			}
+			bySource[location.source] = append(bySource[location.source], Diagnostic{
+				RuleID:     "no-identical-operations",
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("Operation '%s' is identical to %s", name, strings.Join(others, ", ")),
+				Source:     location.source,
+				Operation:  name,
+				Range:      nodeRange(location.definition.Name),
+				Suggestion: "Reuse a single operation so its traffic is counted under one name",
+			})
+		}
+	}
+
+	return bySource
+}
+
+// spansSources reports whether operations come from more than one document
+func spansSources(locations []operationLocation) bool {
+	for _, location := range locations[1:] {
+		if location.source != locations[0].source {
+			return true
+		}
+	}
+	return false
+}
+
+// otherLocations describes every location but the one at index skip
+func otherLocations(locations []operationLocation, skip int) []string {
+	others := make([]string, 0, len(locations)-1)
+	for i, location := range locations {
+		if i != skip {
+			others = append(others, location.describe())
+		}
+	}
+	return others
+}
+
+// operationSignature returns a canonical form of an operation that ignores its
+// name, formatting, fragment names and the order of selections and arguments:
+// spreads are expanded in place as inline fragments, so operations sharing a
+// shape through different fragments match
+func operationSignature(opDef *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition) string {
+	var sb strings.Builder
+	sb.WriteString(opDef.Operation)
+
+	if len(opDef.VariableDefinitions) > 0 {
+		variables := make([]string, 0, len(opDef.VariableDefinitions))
+		for _, variable := range opDef.VariableDefinitions {
+			variables = append(variables, fmt.Sprint(printer.Print(variable)))
+		}
+		sort.Strings(variables)
+		sb.WriteString("(" + strings.Join(variables, ",") + ")")
+	}
+	writeDirectivesSignature(&sb, opDef.Directives)
+	writeSelectionSetSignature(&sb, opDef.SelectionSet, fragments, make(map[string]bool))
+
+	return sb.String()
+}
+
+// writeSelectionSetSignature writes the canonical form of a selection set, with its
+// selections sorted
+func writeSelectionSetSignature(out *strings.Builder, selectionSet *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, spreading map[string]bool) {
+	if selectionSet == nil {
+		return
+	}
+
+	selections := make([]string, 0, len(selectionSet.Selections))
+	for _, selection := range selectionSet.Selections {
+		var sb strings.Builder
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if sel.Alias != nil {
+				sb.WriteString(sel.Alias.Value + ":")
+			}
+			sb.WriteString(sel.Name.Value)
+			if len(sel.Arguments) > 0 {
+				arguments := make([]string, 0, len(sel.Arguments))
+				for _, argument := range sel.Arguments {
+					arguments = append(arguments, fmt.Sprint(printer.Print(argument)))
+				}
+				sort.Strings(arguments)
+				sb.WriteString("(" + strings.Join(arguments, ",") + ")")
+			}
+			writeDirectivesSignature(&sb, sel.Directives)
+			writeSelectionSetSignature(&sb, sel.SelectionSet, fragments, spreading)
+		case *ast.InlineFragment:
+			sb.WriteString("...")
+			if sel.TypeCondition != nil {
+				sb.WriteString(" on " + sel.TypeCondition.Name.Value)
+			}
+			writeDirectivesSignature(&sb, sel.Directives)
+			writeSelectionSetSignature(&sb, sel.SelectionSet, fragments, spreading)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			fragment, exists := fragments[name]
+			if !exists || spreading[name] {
+				// Unknown and cyclic spreads are left by name; validation reports them
+				sb.WriteString("..." + name)
+				writeDirectivesSignature(&sb, sel.Directives)
+				break
+			}
+			sb.WriteString("... on " + fragment.TypeCondition.Name.Value)
+			writeDirectivesSignature(&sb, sel.Directives)
+			writeDirectivesSignature(&sb, fragment.Directives)
+			spreading[name] = true
+			writeSelectionSetSignature(&sb, fragment.SelectionSet, fragments, spreading)
+			delete(spreading, name)
+		}
+		selections = append(selections, sb.String())
+	}
+	sort.Strings(selections)
+	out.WriteString("{" + strings.Join(selections, " ") + "}")
+}
+
+// writeDirectivesSignature writes the canonical form of a list of directives
+func writeDirectivesSignature(sb *strings.Builder, directives []*ast.Directive) {
+	for _, directive := range directives {
+		sb.WriteString(" " + fmt.Sprint(printer.Print(directive)))
+	}
+}
@@ -0,0 +1,103 @@
+package core_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestValidateDocumentsOperationNames(t *testing.T) {
+	schema := mustLoadSchema(t, `type Query { user(id: ID, name: String): User } type User { id: ID name: String }`)
+
+	tests := []struct {
+		name      string
+		documents []string
+		identical bool
+		// want holds the messages expected in each document, keyed by source
+		want map[string][]string
+	}{
+		{
+			name:      "duplicate names across documents",
+			documents: []string{`query GetUser { user { id } }`, `query GetUser { user { name } }`},
+			want: map[string][]string{
+				"doc1.graphql": {"Operation 'GetUser' is also defined at doc2.graphql:1:7"},
+				"doc2.graphql": {"Operation 'GetUser' is also defined at doc1.graphql:1:7"},
+			},
+		},
+		{
+			name:      "duplicate names with the same selections are only reported as duplicates",
+			documents: []string{`query GetUser { user { id } }`, `query GetUser { user { id } }`},
+			identical: true,
+			want: map[string][]string{
+				"doc1.graphql": {"Operation 'GetUser' is also defined at doc2.graphql:1:7"},
+				"doc2.graphql": {"Operation 'GetUser' is also defined at doc1.graphql:1:7"},
+			},
+		},
+		{
+			name:      "identical operations under different names",
+			documents: []string{`query GetUser { user { id name } }`, `query FetchUser { user { id name } }`},
+			identical: true,
+			want: map[string][]string{
+				"doc1.graphql": {"Operation 'GetUser' is identical to 'FetchUser' (doc2.graphql:1:7)"},
+				"doc2.graphql": {"Operation 'FetchUser' is identical to 'GetUser' (doc1.graphql:1:7)"},
+			},
+		},
+		{
+			name: "field and argument order and formatting don't matter",
+			documents: []string{
+				`query GetUser { user(id: 1, name: "a") { id name } }`,
+				"query FetchUser {\n  user(name: \"a\", id: 1) {\n    name\n    id\n  }\n}",
+			},
+			identical: true,
+			want: map[string][]string{
+				"doc1.graphql": {"Operation 'GetUser' is identical to 'FetchUser' (doc2.graphql:1:7)"},
+				"doc2.graphql": {"Operation 'FetchUser' is identical to 'GetUser' (doc1.graphql:1:7)"},
+			},
+		},
+		{
+			name: "fragments are expanded",
+			documents: []string{
+				`query GetUser { user { ...UserFields } } fragment UserFields on User { id name }`,
+				`query FetchUser { user { ...Fields } } fragment Fields on User { name id }`,
+			},
+			identical: true,
+			want: map[string][]string{
+				"doc1.graphql": {"Operation 'GetUser' is identical to 'FetchUser' (doc2.graphql:1:7)"},
+				"doc2.graphql": {"Operation 'FetchUser' is identical to 'GetUser' (doc1.graphql:1:7)"},
+			},
+		},
+		{
+			name:      "aliases and arguments make operations different",
+			documents: []string{`query GetUser { user(id: 1) { id } }`, `query FetchUser { user(id: 2) { id } }`, `query AliasUser { user(id: 1) { userId: id } }`},
+			identical: true,
+			want:      map[string][]string{},
+		},
+		{
+			name:      "identical operations are only reported when asked to",
+			documents: []string{`query GetUser { user { id } }`, `query FetchUser { user { id } }`},
+			want:      map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := core.ValidateDocuments(schema, mustLoadDocuments(t, tt.documents...), &core.ValidateOptions{
+				DetectIdenticalOperations: tt.identical,
+			})
+			if err != nil {
+				t.Fatalf("ValidateDocuments() error = %v", err)
+			}
+
+			got := make(map[string][]string)
+			for _, result := range results {
+				for _, diagnostic := range result.Diagnostics {
+					got[result.Source] = append(got[result.Source], diagnostic.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diagnostics = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,504 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// PruneOptions configures schema pruning
+type PruneOptions struct {
+	// KeepDirective names a directive that protects the type or member carrying
+	// it, along with everything nested under it; "keep" without options
+	KeepDirective string
+	// Allowlist holds coordinate globs or directive patterns to keep, as accepted
+	// by diff --include
+	Allowlist []string
+	// Deprecate marks unused fields and enum values with @deprecated instead of
+	// removing anything
+	Deprecate         bool
+	DeprecationReason string
+}
+
+// PruneResult is a pruned schema along with what pruning changed
+type PruneResult struct {
+	SDL        string   `json:"sdl"`
+	Removed    []string `json:"removed"`
+	Deprecated []string `json:"deprecated"`
+	// Kept lists unused coordinates protected by the keep directive or allowlist
+	Kept []string `json:"kept"`
+}
+
+// PruneSchema prints a schema without the fields, arguments, enum values and input
+// fields that no document uses, and without the types left unreachable from the
+// root types. Object fields implementing a kept interface field are kept, as are
+// the values of enums returned by fields, since documents only use enum values as
+// inputs. Members are only removed from a type if some remain; mutation and
+// subscription types left without fields are removed. In deprecate mode, unused
+// fields and enum values are marked @deprecated instead, and nothing is removed.
+func PruneSchema(schema *Schema, documents []Document, options *PruneOptions) (*PruneResult, error) {
+	if options == nil {
+		options = &PruneOptions{KeepDirective: "keep"}
+	}
+	if options.DeprecationReason == "" {
+		options.DeprecationReason = "unused"
+	}
+
+	coverage, err := AnalyzeCoverage(schema, documents, nil)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseSchemaSDL(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Variables sent without a known value may hold any value or field of their type
+	unknownInputs := make(map[string]bool)
+	walkCoverage(schema, documents, &coverageVisitor{
+		unknownVariable: func(t graphql.Type) {
+			markInputTypes(t, unknownInputs)
+		},
+	})
+
+	patterns := append([]string{}, options.Allowlist...)
+	if options.KeepDirective != "" {
+		patterns = append(patterns, "@"+options.KeepDirective)
+	}
+	pruner := &schemaPruner{
+		coverage:      coverage,
+		options:       options,
+		patterns:      patterns,
+		directives:    directivesByCoordinate(doc),
+		outputEnums:   outputEnumNames(doc),
+		unknownInputs: unknownInputs,
+		result:        &PruneResult{Removed: []string{}, Deprecated: []string{}, Kept: []string{}},
+	}
+
+	// Interfaces go first, so objects keep the fields their interfaces still declare
+	interfaceFields := make(map[string]map[string]bool)
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.InterfaceDefinition); ok {
+			def.Fields, _ = pruner.pruneFields(def.Name.Value, def.Fields, nil, false)
+			interfaceFields[def.Name.Value] = fieldNames(def.Fields)
+		}
+	}
+
+	roots := rootTypeNames(doc)
+	var removedRoots []string
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			var emptied bool
+			removable := roots[def.Name.Value] != "" && roots[def.Name.Value] != "query"
+			def.Fields, emptied = pruner.pruneFields(def.Name.Value, def.Fields, implementedFields(def.Interfaces, interfaceFields), removable)
+			if emptied {
+				removedRoots = append(removedRoots, def.Name.Value)
+			}
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				def.Definition.Fields, _ = pruner.pruneFields(def.Definition.Name.Value, def.Definition.Fields, implementedFields(def.Definition.Interfaces, interfaceFields), false)
+			}
+		case *ast.EnumDefinition:
+			if !pruner.outputEnums[def.Name.Value] {
+				def.Values = pruner.pruneEnumValues(def.Name.Value, def.Values)
+			}
+		case *ast.InputObjectDefinition:
+			def.Fields = pruner.pruneInputFields(def.Name.Value, def.Fields)
+		}
+	}
+
+	if !options.Deprecate {
+		doc.Definitions = pruner.removeUnreachableTypes(doc.Definitions, removedRoots)
+	}
+
+	result := pruner.result
+	result.SDL = printSDL(doc.Definitions)
+	sort.Strings(result.Removed)
+	sort.Strings(result.Deprecated)
+	sort.Strings(result.Kept)
+	return result, nil
+}
+
+// schemaPruner decides what to keep of a schema's definitions
+type schemaPruner struct {
+	coverage    *CoverageResult
+	options     *PruneOptions
+	patterns    []string
+	directives  map[string][]*ast.Directive
+	outputEnums map[string]bool
+	// unknownInputs holds the enum and input types passed through variables
+	// whose values aren't known
+	unknownInputs map[string]bool
+	result        *PruneResult
+}
+
+// covered reports whether documents use a type's member, e.g. "posts" or
+// "posts(first:)". Members whose coverage isn't tracked count as used, as do
+// all members of input types passed through variables with unknown values.
+func (p *schemaPruner) covered(typeName, member string) bool {
+	if p.unknownInputs[typeName] {
+		return true
+	}
+	typeCoverage, exists := p.coverage.Details[typeName]
+	if !exists {
+		return true
+	}
+	for _, members := range []map[string]bool{typeCoverage.Fields, typeCoverage.Arguments, typeCoverage.EnumValues, typeCoverage.InputFields} {
+		if covered, tracked := members[member]; tracked {
+			return covered
+		}
+	}
+	return true
+}
+
+// kept reports whether the keep directive or allowlist protects a coordinate,
+// recording unused coordinates it protects
+func (p *schemaPruner) kept(coordinate string) bool {
+	if matchesAnyCoordinatePattern(coordinate, p.patterns, p.directives) {
+		p.result.Kept = append(p.result.Kept, coordinate)
+		return true
+	}
+	return false
+}
+
+// pruneFields prunes the unused fields of an object or interface type, and the
+// unused arguments of the fields it keeps. Fields in required are kept. Fields
+// are only removed if some remain, unless removable is set, in which case the
+// returned flag reports that none remain.
+func (p *schemaPruner) pruneFields(typeName string, fields []*ast.FieldDefinition, required map[string]bool, removable bool) ([]*ast.FieldDefinition, bool) {
+	var kept, unused []*ast.FieldDefinition
+	for _, field := range fields {
+		coordinate := typeName + "." + field.Name.Value
+		if p.covered(typeName, field.Name.Value) || required[field.Name.Value] || p.kept(coordinate) {
+			field.Arguments = p.pruneArguments(typeName, field)
+			kept = append(kept, field)
+		} else {
+			unused = append(unused, field)
+		}
+	}
+	if p.options.Deprecate {
+		for _, field := range unused {
+			if p.deprecate(&field.Directives) {
+				p.result.Deprecated = append(p.result.Deprecated, typeName+"."+field.Name.Value)
+			}
+		}
+		return fields, false
+	}
+
+	if len(unused) == 0 || (len(kept) == 0 && !removable) {
+		return fields, false
+	}
+	if len(kept) == 0 {
+		return nil, true // The type goes, and is reported instead of its fields
+	}
+	for _, field := range unused {
+		p.result.Removed = append(p.result.Removed, typeName+"."+field.Name.Value)
+	}
+	return kept, false
+}
+
+// pruneArguments removes the unused arguments of a field
+func (p *schemaPruner) pruneArguments(typeName string, field *ast.FieldDefinition) []*ast.InputValueDefinition {
+	if p.options.Deprecate {
+		return field.Arguments
+	}
+
+	var kept []*ast.InputValueDefinition
+	for _, arg := range field.Arguments {
+		key := argumentKey(field.Name.Value, arg.Name.Value)
+		if p.covered(typeName, key) || p.kept(typeName+"."+key) {
+			kept = append(kept, arg)
+			continue
+		}
+		p.result.Removed = append(p.result.Removed, typeName+"."+key)
+	}
+	return kept
+}
+
+// pruneEnumValues prunes the unused values of an enum, if some remain
+func (p *schemaPruner) pruneEnumValues(typeName string, values []*ast.EnumValueDefinition) []*ast.EnumValueDefinition {
+	var kept, unused []*ast.EnumValueDefinition
+	for _, value := range values {
+		if p.covered(typeName, value.Name.Value) || p.kept(typeName+"."+value.Name.Value) {
+			kept = append(kept, value)
+		} else {
+			unused = append(unused, value)
+		}
+	}
+	if p.options.Deprecate {
+		for _, value := range unused {
+			if p.deprecate(&value.Directives) {
+				p.result.Deprecated = append(p.result.Deprecated, typeName+"."+value.Name.Value)
+			}
+		}
+		return values
+	}
+
+	if len(unused) == 0 || len(kept) == 0 {
+		return values
+	}
+
+	for _, value := range unused {
+		p.result.Removed = append(p.result.Removed, typeName+"."+value.Name.Value)
+	}
+	return kept
+}
+
+// pruneInputFields removes the unused fields of an input type, if some remain
+func (p *schemaPruner) pruneInputFields(typeName string, fields []*ast.InputValueDefinition) []*ast.InputValueDefinition {
+	if p.options.Deprecate {
+		return fields
+	}
+
+	var kept, unused []*ast.InputValueDefinition
+	for _, field := range fields {
+		if p.covered(typeName, field.Name.Value) || p.kept(typeName+"."+field.Name.Value) {
+			kept = append(kept, field)
+		} else {
+			unused = append(unused, field)
+		}
+	}
+	if len(unused) == 0 || len(kept) == 0 {
+		return fields
+	}
+
+	for _, field := range unused {
+		p.result.Removed = append(p.result.Removed, typeName+"."+field.Name.Value)
+	}
+	return kept
+}
+
+// deprecate adds @deprecated to a member that isn't deprecated yet
+func (p *schemaPruner) deprecate(directives *[]*ast.Directive) bool {
+	if hasDirective(*directives, "deprecated") {
+		return false
+	}
+	*directives = append(*directives, ast.NewDirective(&ast.Directive{
+		Name: ast.NewName(&ast.Name{Value: "deprecated"}),
+		Arguments: []*ast.Argument{ast.NewArgument(&ast.Argument{
+			Name:  ast.NewName(&ast.Name{Value: "reason"}),
+			Value: ast.NewStringValue(&ast.StringValue{Value: p.options.DeprecationReason}),
+		})},
+	}))
+	return true
+}
+
+// removeUnreachableTypes removes the given root types, and the types that can no
+// longer be reached from the remaining root types, kept types or directive arguments
+func (p *schemaPruner) removeUnreachableTypes(defs []ast.Node, removedRoots []string) []ast.Node {
+	removed := make(map[string]bool)
+	for _, name := range removedRoots {
+		removed[name] = true
+	}
+
+	var roots []string
+	for name := range rootTypeNames(&ast.Document{Definitions: defs}) {
+		if !removed[name] {
+			roots = append(roots, name)
+		}
+	}
+	for _, def := range defs {
+		if directive, ok := def.(*ast.DirectiveDefinition); ok {
+			for _, arg := range directive.Arguments {
+				roots = append(roots, baseTypeName(arg.Type))
+			}
+			continue
+		}
+		if name := definitionName(def); name != "schema" && !removed[name] && matchesAnyCoordinatePattern(name, p.patterns, p.directives) {
+			roots = append(roots, name)
+		}
+	}
+	reachable := reachableDefinitions(defs, roots)
+
+	kept := make([]ast.Node, 0, len(defs))
+	for _, def := range defs {
+		switch def := def.(type) {
+		case *ast.DirectiveDefinition:
+			// Directive definitions always stay
+		case *ast.SchemaDefinition:
+			var operations []*ast.OperationTypeDefinition
+			for _, operation := range def.OperationTypes {
+				if !removed[operation.Type.Name.Value] {
+					operations = append(operations, operation)
+				}
+			}
+			def.OperationTypes = operations
+		default:
+			name := definitionName(def)
+			if !reachable[name] {
+				if _, extension := def.(*ast.TypeExtensionDefinition); !extension {
+					p.result.Removed = append(p.result.Removed, name)
+				}
+				continue
+			}
+		}
+		kept = append(kept, def)
+	}
+	return kept
+}
+
+// rootTypeNames returns the root operation types of a schema document by name,
+// mapped to their operation, from the schema definition or the default names
+func rootTypeNames(doc *ast.Document) map[string]string {
+	roots := make(map[string]string)
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.SchemaDefinition); ok {
+			for _, operation := range def.OperationTypes {
+				roots[operation.Type.Name.Value] = operation.Operation
+			}
+			return roots
+		}
+	}
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.ObjectDefinition); ok {
+			switch def.Name.Value {
+			case "Query", "Mutation", "Subscription":
+				roots[def.Name.Value] = strings.ToLower(def.Name.Value)
+			}
+		}
+	}
+	return roots
+}
+
+// reachableDefinitions returns the names of the types reachable from the roots
+// through fields, arguments, implemented interfaces, union members and the
+// implementations of interfaces. It follows the same edges as ReachableTypes, but
+// walks the pruned definitions: they are only AST until printed, and building a
+// graphql.Schema from SDL is left to the loader package.
+func reachableDefinitions(defs []ast.Node, roots []string) map[string]bool {
+	references := make(map[string][]string)
+	addFields := func(name string, fields []*ast.FieldDefinition) {
+		for _, field := range fields {
+			references[name] = append(references[name], baseTypeName(field.Type))
+			for _, arg := range field.Arguments {
+				references[name] = append(references[name], baseTypeName(arg.Type))
+			}
+		}
+	}
+	addObject := func(def *ast.ObjectDefinition) {
+		addFields(def.Name.Value, def.Fields)
+		for _, iface := range def.Interfaces {
+			references[def.Name.Value] = append(references[def.Name.Value], iface.Name.Value)
+			references[iface.Name.Value] = append(references[iface.Name.Value], def.Name.Value)
+		}
+	}
+	for _, def := range defs {
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			addObject(def)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				addObject(def.Definition)
+			}
+		case *ast.InterfaceDefinition:
+			addFields(def.Name.Value, def.Fields)
+		case *ast.UnionDefinition:
+			for _, member := range def.Types {
+				references[def.Name.Value] = append(references[def.Name.Value], member.Name.Value)
+			}
+		case *ast.InputObjectDefinition:
+			for _, field := range def.Fields {
+				references[def.Name.Value] = append(references[def.Name.Value], baseTypeName(field.Type))
+			}
+		}
+	}
+
+	reachable := make(map[string]bool)
+	queue := append([]string{}, roots...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if reachable[name] {
+			continue
+		}
+		reachable[name] = true
+		queue = append(queue, references[name]...)
+	}
+	return reachable
+}
+
+// outputEnumNames returns the enums that fields return
+func outputEnumNames(doc *ast.Document) map[string]bool {
+	enums := make(map[string]bool)
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.EnumDefinition); ok {
+			enums[def.Name.Value] = true
+		}
+	}
+
+	outputs := make(map[string]bool)
+	addFields := func(fields []*ast.FieldDefinition) {
+		for _, field := range fields {
+			if name := baseTypeName(field.Type); enums[name] {
+				outputs[name] = true
+			}
+		}
+	}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			addFields(def.Fields)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				addFields(def.Definition.Fields)
+			}
+		case *ast.InterfaceDefinition:
+			addFields(def.Fields)
+		}
+	}
+	return outputs
+}
+
+// implementedFields returns the fields the given interfaces declare
+func implementedFields(interfaces []*ast.Named, interfaceFields map[string]map[string]bool) map[string]bool {
+	fields := make(map[string]bool)
+	for _, iface := range interfaces {
+		for name := range interfaceFields[iface.Name.Value] {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// fieldNames returns the names of a list of field definitions
+func fieldNames(fields []*ast.FieldDefinition) map[string]bool {
+	names := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		names[field.Name.Value] = true
+	}
+	return names
+}
+
+// markInputTypes marks an enum or input type, and the input types its fields
+// take, as able to hold any value
+func markInputTypes(t graphql.Type, marked map[string]bool) {
+	named := unwrapType(t)
+	if named == nil || marked[named.Name()] {
+		return
+	}
+	switch named := named.(type) {
+	case *graphql.Enum:
+		marked[named.Name()] = true
+	case *graphql.InputObject:
+		marked[named.Name()] = true
+		for _, field := range named.Fields() {
+			markInputTypes(field.Type, marked)
+		}
+	}
+}
+
+// baseTypeName strips list and non-null wrappers from a type reference
+func baseTypeName(t ast.Type) string {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return baseTypeName(t.Type)
+	case *ast.List:
+		return baseTypeName(t.Type)
+	case *ast.Named:
+		return t.Name.Value
+	}
+	return ""
+}
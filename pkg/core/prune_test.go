@@ -0,0 +1,174 @@
+package core_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+	"github.com/bishnuag/graphql-inspector/pkg/loader"
+)
+
+func TestPruneSchema(t *testing.T) {
+	tests := []struct {
+		name           string
+		sdl            string
+		documents      []string
+		options        *core.PruneOptions
+		wantRemoved    []string
+		wantDeprecated []string
+		wantKept       []string
+		contains       []string
+		excludes       []string
+	}{
+		{
+			name: "fields required by interfaces are kept",
+			sdl: `
+type Query { node: Node user: User }
+interface Node { id: ID! name: String }
+type User implements Node { id: ID! name: String email: String }
+`,
+			documents:   []string{`{ node { id } user { name } }`},
+			wantRemoved: []string{"Node.name", "User.email"},
+			contains:    []string{"type User implements Node {\n  id: ID!\n  name: String\n}"},
+		},
+		{
+			name: "output enum values are never removed",
+			sdl: `
+type Query { users(role: Role): [User] }
+type User { status: Status }
+enum Status { ACTIVE INACTIVE }
+enum Role { ADMIN USER }
+`,
+			documents:   []string{`{ users(role: ADMIN) { status } }`},
+			wantRemoved: []string{"Role.USER"},
+			contains:    []string{"enum Status {\n  ACTIVE\n  INACTIVE\n}", "enum Role {\n  ADMIN\n}"},
+		},
+		{
+			name: "emptied mutation roots are removed and queries keep their last field",
+			sdl: `
+schema { query: Query mutation: Mutation }
+type Query { ping: String }
+type Mutation { reset(input: ResetInput): Boolean }
+input ResetInput { force: Boolean }
+`,
+			documents:   []string{`query Other { __typename }`},
+			wantRemoved: []string{"Mutation", "ResetInput"},
+			contains:    []string{"schema {\n  query: Query\n}", "type Query {\n  ping: String\n}"},
+			excludes:    []string{"Mutation", "ResetInput"},
+		},
+		{
+			name: "unused arguments, input fields and unreachable types are removed",
+			sdl: `
+type Query { users(first: Int, filter: UserFilter): [User] legacy: Legacy }
+type User { id: ID }
+type Legacy { id: ID }
+type Orphan { id: ID }
+input UserFilter { name: String active: Boolean }
+`,
+			documents:   []string{`{ users(filter: { name: "a" }) { id } }`},
+			wantRemoved: []string{"Legacy", "Orphan", "Query.legacy", "Query.users(first:)", "UserFilter.active"},
+			contains:    []string{"users(filter: UserFilter): [User]", "input UserFilter {\n  name: String\n}"},
+		},
+		{
+			name: "enum values and input fields passed through unknown variables are kept",
+			sdl: `
+type Query { users(role: Role, filter: UserFilter): [User] }
+type User { id: ID }
+enum Role { ADMIN USER }
+input UserFilter { name: String active: Boolean }
+`,
+			documents: []string{
+				`query A { users(role: ADMIN, filter: { name: "x" }) { id } }`,
+				`query B($role: Role, $f: UserFilter) { users(role: $role, filter: $f) { id } }`,
+			},
+			contains: []string{"enum Role {\n  ADMIN\n  USER\n}", "input UserFilter {\n  name: String\n  active: Boolean\n}"},
+		},
+		{
+			name: "keep directive protects members and whole types",
+			sdl: `
+directive @keep on FIELD_DEFINITION | OBJECT
+type Query { users: [User] health: String @keep }
+type User { id: ID email: String }
+type Audit @keep { when: String }
+`,
+			documents:   []string{`{ users { id } }`},
+			wantRemoved: []string{"User.email"},
+			wantKept:    []string{"Audit.when", "Query.health"},
+			contains:    []string{"health: String @keep", "type Audit @keep {\n  when: String\n}"},
+		},
+		{
+			name: "custom keep directive and allowlist globs",
+			sdl: `
+directive @preserve on FIELD_DEFINITION
+type Query { users: [User] admin: Admin }
+type User { id: ID email: String @preserve name: String }
+type Admin { id: ID }
+`,
+			documents:   []string{`{ users { id } }`},
+			options:     &core.PruneOptions{KeepDirective: "preserve", Allowlist: []string{"Admin*", "Query.admin"}},
+			wantRemoved: []string{"User.name"},
+			wantKept:    []string{"Admin.id", "Query.admin", "User.email"},
+			contains:    []string{"admin: Admin", "type Admin {\n  id: ID\n}"},
+		},
+		{
+			name: "deprecate mode marks fields and enum values without removing anything",
+			sdl: `
+type Query { users(first: Int, role: Role): [User] }
+type User { id: ID email: String }
+enum Role { ADMIN USER }
+type Orphan { id: ID }
+`,
+			documents:      []string{`{ users(role: ADMIN) { id } }`},
+			options:        &core.PruneOptions{Deprecate: true, DeprecationReason: "unused since v2"},
+			wantDeprecated: []string{"Orphan.id", "Role.USER", "User.email"},
+			contains: []string{
+				`email: String @deprecated(reason: "unused since v2")`,
+				`USER @deprecated(reason: "unused since v2")`,
+				"users(first: Int, role: Role): [User]",
+				"type Orphan",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := mustLoadSchema(t, tt.sdl)
+			result, err := core.PruneSchema(schema, mustLoadDocuments(t, tt.documents...), tt.options)
+			if err != nil {
+				t.Fatalf("PruneSchema() error = %v", err)
+			}
+
+			for _, check := range []struct {
+				name      string
+				got, want []string
+			}{
+				{"Removed", result.Removed, tt.wantRemoved},
+				{"Deprecated", result.Deprecated, tt.wantDeprecated},
+				{"Kept", result.Kept, tt.wantKept},
+			} {
+				if check.want == nil {
+					check.want = []string{}
+				}
+				if !reflect.DeepEqual(check.got, check.want) {
+					t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+				}
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(result.SDL, want) {
+					t.Errorf("pruned SDL is missing %q:\n%s", want, result.SDL)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(result.SDL, unwanted) {
+					t.Errorf("pruned SDL still contains %q:\n%s", unwanted, result.SDL)
+				}
+			}
+
+			// The pruned schema must still build
+			if _, err := loader.LoadSchemaFromContent(result.SDL); err != nil {
+				t.Errorf("pruned SDL doesn't load: %v\n%s", err, result.SDL)
+			}
+		})
+	}
+}
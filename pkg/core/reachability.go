@@ -0,0 +1,195 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// OrphanType represents a type that can't be reached from any root operation type
+type OrphanType struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// New reports whether the type was reachable, or didn't exist, in the previous schema
+	New bool `json:"new"`
+}
+
+// builtInScalars are the scalars every schema gets without defining them
+var builtInScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// isBuiltInType reports whether a type is a specified scalar or an introspection type
+func isBuiltInType(name string) bool {
+	return builtInScalars[name] || strings.HasPrefix(name, "__")
+}
+
+// ReachableTypes returns the names of the types reachable from the query, mutation
+// and subscription types through fields, arguments, implemented interfaces, union
+// members and the possible types of interfaces. Types used by directive arguments
+// are treated as reachable too, since directives are part of the schema's surface.
+func ReachableTypes(schema *graphql.Schema) map[string]bool {
+	reachable := make(map[string]bool)
+	if schema == nil {
+		return reachable
+	}
+
+	var queue []graphql.Type
+	visit := func(t graphql.Type) {
+		named := unwrapType(t)
+		if named == nil || reachable[named.Name()] {
+			return
+		}
+		reachable[named.Name()] = true
+		queue = append(queue, named)
+	}
+
+	if schema.QueryType() != nil {
+		visit(schema.QueryType())
+	}
+	if schema.MutationType() != nil {
+		visit(schema.MutationType())
+	}
+	if schema.SubscriptionType() != nil {
+		visit(schema.SubscriptionType())
+	}
+	for _, directive := range schema.Directives() {
+		for _, arg := range directive.Args {
+			visit(arg.Type)
+		}
+	}
+
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+
+		switch t := t.(type) {
+		case *graphql.Object:
+			visitFields(t.Fields(), visit)
+			for _, iface := range t.Interfaces() {
+				visit(iface)
+			}
+		case *graphql.Interface:
+			visitFields(t.Fields(), visit)
+			for _, possible := range schema.PossibleTypes(t) {
+				visit(possible)
+			}
+		case *graphql.Union:
+			for _, member := range t.Types() {
+				visit(member)
+			}
+		case *graphql.InputObject:
+			for _, field := range t.Fields() {
+				visit(field.Type)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// unwrapType strips list and non-null wrappers from a type
+func unwrapType(t graphql.Type) graphql.Type {
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			t = wrapped.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// visitFields visits the types of fields and their arguments
+func visitFields(fields graphql.FieldDefinitionMap, visit func(graphql.Type)) {
+	for _, field := range fields {
+		visit(field.Type)
+		for _, arg := range field.Args {
+			visit(arg.Type)
+		}
+	}
+}
+
+// FindOrphanTypes returns the types of a schema that can't be reached from its root
+// types, sorted by name. When a previous schema is given, orphans that were reachable
+// or missing in it are marked as new.
+func FindOrphanTypes(schema, previous *Schema) []OrphanType {
+	if schema == nil || schema.Schema == nil {
+		return nil
+	}
+
+	reachable := ReachableTypes(schema.Schema)
+
+	var previousOrphans map[string]bool
+	if previous != nil && previous.Schema != nil {
+		previousOrphans = make(map[string]bool)
+		previousReachable := ReachableTypes(previous.Schema)
+		for name := range previous.Schema.TypeMap() {
+			if !previousReachable[name] {
+				previousOrphans[name] = true
+			}
+		}
+	}
+
+	var orphans []OrphanType
+	for name, t := range schema.Schema.TypeMap() {
+		if reachable[name] || isBuiltInType(name) {
+			continue
+		}
+		orphans = append(orphans, OrphanType{
+			Name: name,
+			Kind: getTypeKind(t),
+			New:  previousOrphans != nil && !previousOrphans[name],
+		})
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Name < orphans[j].Name
+	})
+
+	return orphans
+}
+
+// annotateReachability marks changes to types that can't be reached from the root
+// types of either schema, and downgrades them to non-breaking when asked to: clients
+// can't select anything from such types, so changing them can't break an operation.
+func annotateReachability(changes []Change, oldSchema, newSchema *graphql.Schema, options *DiffOptions) []Change {
+	oldReachable := ReachableTypes(oldSchema)
+	newReachable := ReachableTypes(newSchema)
+
+	for i, change := range changes {
+		if change.Path == "" || strings.HasPrefix(change.Path, "@") {
+			continue
+		}
+
+		typeName, _, _ := parseCoordinate(change.Path)
+		if oldReachable[typeName] || newReachable[typeName] {
+			continue
+		}
+
+		meta := make(map[string]interface{}, len(change.Meta)+2)
+		for key, value := range change.Meta {
+			meta[key] = value
+		}
+		meta["reachable"] = false
+
+		change.Message += " (unreachable from root types)"
+		if options.DowngradeUnreachable && change.Type != ChangeTypeNonBreaking {
+			meta["originalType"] = change.Type
+			change.Type = ChangeTypeNonBreaking
+			change.Criticality = "LOW"
+		}
+		change.Meta = meta
+
+		changes[i] = change
+	}
+
+	return changes
+}
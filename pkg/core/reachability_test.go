@@ -0,0 +1,84 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestDiffSchemasUnreachableChanges(t *testing.T) {
+	oldSchema := mustLoadSchema(t, `
+type Query { user: User }
+type User { id: ID name: String }
+type Orphan { id: ID name: String }
+`)
+	newSchema := mustLoadSchema(t, `
+type Query { ping: String }
+type User { id: ID }
+type Orphan { id: ID }
+`)
+
+	tests := []struct {
+		name      string
+		downgrade bool
+		want      map[string]core.ChangeType
+	}{
+		{
+			name: "unreachable changes are annotated",
+			want: map[string]core.ChangeType{
+				"Query.user":  core.ChangeTypeBreaking,
+				"User.name":   core.ChangeTypeBreaking,
+				"Orphan.name": core.ChangeTypeBreaking,
+			},
+		},
+		{
+			name:      "unreachable changes are downgraded when asked to",
+			downgrade: true,
+			want: map[string]core.ChangeType{
+				"Query.user": core.ChangeTypeBreaking,
+				// User was reachable in the old schema, so removing its fields can break operations
+				"User.name":   core.ChangeTypeBreaking,
+				"Orphan.name": core.ChangeTypeNonBreaking,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := core.DiffSchemas(oldSchema, newSchema, &core.DiffOptions{DowngradeUnreachable: tt.downgrade})
+			if err != nil {
+				t.Fatalf("DiffSchemas() error = %v", err)
+			}
+
+			byPath := make(map[string]core.Change)
+			for _, change := range changes {
+				byPath[change.Path] = change
+			}
+			for path, want := range tt.want {
+				change, exists := byPath[path]
+				if !exists {
+					t.Errorf("no change at %s in %+v", path, changes)
+					continue
+				}
+				if change.Type != want {
+					t.Errorf("%s type = %s, want %s", path, change.Type, want)
+				}
+
+				unreachable := path == "Orphan.name"
+				if got := strings.HasSuffix(change.Message, " (unreachable from root types)"); got != unreachable {
+					t.Errorf("%s message = %q, want unreachable annotation %v", path, change.Message, unreachable)
+				}
+				if reachable, annotated := change.Meta["reachable"]; annotated != unreachable || (annotated && reachable != false) {
+					t.Errorf("%s meta = %v, want reachable=false only when unreachable", path, change.Meta)
+				}
+				if original, downgraded := change.Meta["originalType"]; downgraded != (unreachable && tt.downgrade) || (downgraded && original != core.ChangeTypeBreaking) {
+					t.Errorf("%s meta = %v, want originalType BREAKING only when downgraded", path, change.Meta)
+				}
+				if unreachable && tt.downgrade && change.Criticality != "LOW" {
+					t.Errorf("%s criticality = %q, want LOW", path, change.Criticality)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ResponseCheckResult represents the result of checking a recorded response
+// against the operation that produced it
+type ResponseCheckResult struct {
+	Source      string       `json:"source"`
+	Operation   string       `json:"operation"`
+	IsValid     bool         `json:"isValid"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// CheckResponse checks that a JSON response matches the selections of an
+// operation: every selected field is present, nulls only appear where the schema
+// allows them, lists, enum values and scalars have the right shape, and
+// __typename values are possible for their abstract types. The operation may be
+// omitted when the document defines only one.
+func CheckResponse(schema *Schema, doc Document, operationName string, response map[string]interface{}) (*ResponseCheckResult, error) {
+	if schema == nil || schema.Schema == nil {
+		return nil, fmt.Errorf("schema is required")
+	}
+
+	docAST, err := parseDocument(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	opDef, err := selectOperation(docAST, operationName)
+	if err != nil {
+		return nil, err
+	}
+
+	root := rootType(schema.Schema, opDef)
+	if root == nil {
+		return nil, fmt.Errorf("schema has no %s type", opDef.Operation)
+	}
+
+	checker := &responseChecker{
+		schema:    schema.Schema,
+		fragments: fragmentDefinitions(docAST),
+		operation: getOperationName(opDef),
+	}
+
+	data, exists := response["data"]
+	switch {
+	case !exists:
+		checker.report(opDef, SeverityError, "Response has no 'data' entry", "")
+	case data == nil:
+		checker.report(opDef, SeverityError, "Response data is null", responseErrorsSuggestion(response))
+	default:
+		checker.checkValue(data, graphql.NewNonNull(root), []*ast.SelectionSet{opDef.SelectionSet}, "data", opDef)
+	}
+
+	for i := range checker.diagnostics {
+		checker.diagnostics[i].Source = doc.Source
+	}
+
+	return &ResponseCheckResult{
+		Source:      doc.Source,
+		Operation:   checker.operation,
+		IsValid:     !HasErrors(checker.diagnostics),
+		Diagnostics: checker.diagnostics,
+	}, nil
+}
+
+// selectOperation returns the named operation of a document, or its only operation
+func selectOperation(docAST *ast.Document, operationName string) (*ast.OperationDefinition, error) {
+	var operations []*ast.OperationDefinition
+	for _, def := range docAST.Definitions {
+		if opDef, ok := def.(*ast.OperationDefinition); ok {
+			if operationName != "" && opDef.Name != nil && opDef.Name.Value == operationName {
+				return opDef, nil
+			}
+			operations = append(operations, opDef)
+		}
+	}
+
+	switch {
+	case operationName != "":
+		return nil, fmt.Errorf("operation '%s' not found in document", operationName)
+	case len(operations) == 0:
+		return nil, fmt.Errorf("document defines no operations")
+	case len(operations) > 1:
+		return nil, fmt.Errorf("document defines %d operations, choose one by name", len(operations))
+	}
+	return operations[0], nil
+}
+
+// responseErrorsSuggestion quotes the first error of a response, if it has any
+func responseErrorsSuggestion(response map[string]interface{}) string {
+	errs, _ := response["errors"].([]interface{})
+	if len(errs) == 0 {
+		return ""
+	}
+	if first, ok := errs[0].(map[string]interface{}); ok {
+		if message, ok := first["message"].(string); ok {
+			return fmt.Sprintf("The response reports %d errors, starting with: %s", len(errs), message)
+		}
+	}
+	return fmt.Sprintf("The response reports %d errors", len(errs))
+}
+
+// responseChecker walks a response alongside the selections that produced it
+type responseChecker struct {
+	schema      *graphql.Schema
+	fragments   map[string]*ast.FragmentDefinition
+	operation   string
+	diagnostics []Diagnostic
+}
+
+// responseField is the set of fields merged into one response key
+type responseField struct {
+	fields []*ast.Field
+	// parent is the type defining the field
+	parent graphql.Type
+	// conditional fields may be absent: they are skipped or included by a
+	// directive, or selected through a fragment that may not apply
+	conditional bool
+}
+
+// report records a diagnostic for a node of the operation
+func (c *responseChecker) report(node ast.Node, severity Severity, message, suggestion string) {
+	c.diagnostics = append(c.diagnostics, Diagnostic{
+		RuleID:     "response",
+		Severity:   severity,
+		Message:    message,
+		Operation:  c.operation,
+		Range:      nodeRange(node),
+		Suggestion: suggestion,
+	})
+}
+
+// checkValue checks a response value against the output type of the field that produced it
+func (c *responseChecker) checkValue(value interface{}, t graphql.Type, selectionSets []*ast.SelectionSet, path string, node ast.Node) {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		if value == nil {
+			c.report(node, SeverityError, fmt.Sprintf("'%s' is null, but its type %s is non-null", path, t), "")
+			return
+		}
+		t = nonNull.OfType
+	}
+	if value == nil {
+		return
+	}
+
+	switch t := t.(type) {
+	case *graphql.List:
+		items, ok := value.([]interface{})
+		if !ok {
+			c.report(node, SeverityError, fmt.Sprintf("'%s' should be a list of type %s, got %s", path, t, jsonValue(value)), "")
+			return
+		}
+		for i, item := range items {
+			c.checkValue(item, t.OfType, selectionSets, fmt.Sprintf("%s[%d]", path, i), node)
+		}
+
+	case *graphql.Object, *graphql.Interface, *graphql.Union:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			c.report(node, SeverityError, fmt.Sprintf("'%s' should be an object of type %s, got %s", path, t, jsonValue(value)), "")
+			return
+		}
+		c.checkObject(object, t, selectionSets, path)
+
+	case *graphql.Enum:
+		var values []string
+		for _, enumValue := range t.Values() {
+			values = append(values, enumValue.Name)
+		}
+		if name, ok := value.(string); !ok || !containsString(values, name) {
+			c.report(node, SeverityError, fmt.Sprintf("'%s' should be a value of enum %s, got %s", path, t.Name(), jsonValue(value)),
+				fmt.Sprintf("Valid values are: %s", strings.Join(values, ", ")))
+		}
+
+	case *graphql.Scalar:
+		if !isValidScalarValue(t.Name(), value) {
+			c.report(node, SeverityError, fmt.Sprintf("'%s' should be a value of type %s, got %s", path, t.Name(), jsonValue(value)), "")
+		}
+	}
+}
+
+// checkObject checks the entries of a response object against the selections made on its type
+func (c *responseChecker) checkObject(object map[string]interface{}, t graphql.Type, selectionSets []*ast.SelectionSet, path string) {
+	// The concrete type of an abstract type is only known from __typename
+	var runtime *graphql.Object
+	if obj, ok := t.(*graphql.Object); ok {
+		runtime = obj
+	}
+	if typename, ok := object["__typename"].(string); ok {
+		if possible := c.possibleType(t, typename); possible != nil {
+			runtime = possible
+		} else {
+			c.report(c.typenameNode(selectionSets), SeverityError, fmt.Sprintf("'%s.__typename' is '%s', which is not a possible type of %s", path, typename, t),
+				fmt.Sprintf("Possible types are: %s", strings.Join(c.possibleTypeNames(t), ", ")))
+		}
+	}
+
+	var keys []string
+	fields := make(map[string]*responseField)
+	for _, selectionSet := range selectionSets {
+		c.collectFields(t, runtime, selectionSet, false, make(map[string]bool), fields, &keys)
+	}
+
+	for _, key := range keys {
+		field := fields[key]
+		fieldPath := path + "." + key
+		node := field.fields[0]
+
+		value, exists := object[key]
+		if !exists {
+			if !field.conditional {
+				c.report(node, SeverityError, fmt.Sprintf("'%s' is missing from the response", fieldPath), "")
+			}
+			continue
+		}
+
+		name := node.Name.Value
+		if name == "__typename" {
+			if _, ok := value.(string); !ok {
+				c.report(node, SeverityError, fmt.Sprintf("'%s' should be a type name, got %s", fieldPath, jsonValue(value)), "")
+			}
+			continue
+		}
+
+		fieldDef := fieldDefinition(field.parent, name)
+		if fieldDef == nil {
+			continue // Unknown fields are reported by validate
+		}
+		var subSelections []*ast.SelectionSet
+		for _, f := range field.fields {
+			if f.SelectionSet != nil {
+				subSelections = append(subSelections, f.SelectionSet)
+			}
+		}
+		c.checkValue(value, fieldDef.Type, subSelections, fieldPath, node)
+	}
+
+	var unexpected []string
+	for key := range object {
+		if _, selected := fields[key]; !selected {
+			unexpected = append(unexpected, key)
+		}
+	}
+	sort.Strings(unexpected)
+	for _, key := range unexpected {
+		c.report(c.firstSelection(selectionSets), SeverityWarning, fmt.Sprintf("'%s.%s' is in the response but was not selected", path, key), "")
+	}
+}
+
+// collectFields gathers the fields selected on an object by response key, in
+// selection order, following fragments that apply to its type
+func (c *responseChecker) collectFields(t graphql.Type, runtime *graphql.Object, selectionSet *ast.SelectionSet, conditional bool, spreading map[string]bool, fields map[string]*responseField, keys *[]string) {
+	if selectionSet == nil {
+		return
+	}
+
+	for _, selection := range selectionSet.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			key := sel.Name.Value
+			if sel.Alias != nil {
+				key = sel.Alias.Value
+			}
+			fieldConditional := conditional || hasConditionalDirective(sel.Directives)
+
+			field, exists := fields[key]
+			if !exists {
+				parent := t
+				if runtime != nil {
+					parent = runtime
+				}
+				field = &responseField{parent: parent, conditional: fieldConditional}
+				fields[key] = field
+				*keys = append(*keys, key)
+			}
+			field.fields = append(field.fields, sel)
+			field.conditional = field.conditional && fieldConditional
+
+		case *ast.InlineFragment:
+			condition := ""
+			if sel.TypeCondition != nil {
+				condition = sel.TypeCondition.Name.Value
+			}
+			c.collectFragmentFields(t, runtime, condition, sel.SelectionSet, conditional || hasConditionalDirective(sel.Directives), spreading, fields, keys)
+
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			fragment, exists := c.fragments[name]
+			if !exists || spreading[name] {
+				continue
+			}
+			spreading[name] = true
+			c.collectFragmentFields(t, runtime, fragment.TypeCondition.Name.Value, fragment.SelectionSet,
+				conditional || hasConditionalDirective(sel.Directives), spreading, fields, keys)
+			delete(spreading, name)
+		}
+	}
+}
+
+// collectFragmentFields gathers the fields of a fragment when it applies to the
+// object. Without a known concrete type, fragments on other types may or may
+// not apply, so their fields are collected as conditional.
+func (c *responseChecker) collectFragmentFields(t graphql.Type, runtime *graphql.Object, condition string, selectionSet *ast.SelectionSet, conditional bool, spreading map[string]bool, fields map[string]*responseField, keys *[]string) {
+	if condition == "" || condition == t.Name() {
+		c.collectFields(t, runtime, selectionSet, conditional, spreading, fields, keys)
+		return
+	}
+
+	conditionType := c.schema.Type(condition)
+	if conditionType == nil {
+		return
+	}
+	if runtime != nil {
+		if runtime.Name() == condition || c.possibleType(conditionType, runtime.Name()) != nil {
+			c.collectFields(t, runtime, selectionSet, conditional, spreading, fields, keys)
+		}
+		return
+	}
+	c.collectFields(conditionType, nil, selectionSet, true, spreading, fields, keys)
+}
+
+// possibleType returns the object type named by a __typename when it is a
+// possible type of t, or nil
+func (c *responseChecker) possibleType(t graphql.Type, typename string) *graphql.Object {
+	object, ok := c.schema.Type(typename).(*graphql.Object)
+	if !ok {
+		return nil
+	}
+	switch t := t.(type) {
+	case *graphql.Object:
+		if t.Name() == typename {
+			return object
+		}
+	case *graphql.Interface:
+		if c.schema.IsPossibleType(t, object) {
+			return object
+		}
+	case *graphql.Union:
+		if c.schema.IsPossibleType(t, object) {
+			return object
+		}
+	}
+	return nil
+}
+
+// possibleTypeNames lists the object types a value of type t may have
+func (c *responseChecker) possibleTypeNames(t graphql.Type) []string {
+	var names []string
+	switch t := t.(type) {
+	case *graphql.Object:
+		names = append(names, t.Name())
+	case *graphql.Interface:
+		for _, object := range c.schema.PossibleTypes(t) {
+			names = append(names, object.Name())
+		}
+	case *graphql.Union:
+		for _, object := range c.schema.PossibleTypes(t) {
+			names = append(names, object.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// typenameNode returns the __typename selection of a set of selections, falling
+// back to the first selection
+func (c *responseChecker) typenameNode(selectionSets []*ast.SelectionSet) ast.Node {
+	for _, selectionSet := range selectionSets {
+		for _, selection := range selectionSet.Selections {
+			if field, ok := selection.(*ast.Field); ok && field.Name.Value == "__typename" {
+				return field
+			}
+		}
+	}
+	return c.firstSelection(selectionSets)
+}
+
+// firstSelection returns the first selection of a set of selections, if any
+func (c *responseChecker) firstSelection(selectionSets []*ast.SelectionSet) ast.Node {
+	for _, selectionSet := range selectionSets {
+		if len(selectionSet.Selections) > 0 {
+			if node, ok := selectionSet.Selections[0].(ast.Node); ok {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+// hasConditionalDirective reports whether a selection carries @skip or @include
+func hasConditionalDirective(directives []*ast.Directive) bool {
+	return findDirective(directives, "skip") != nil || findDirective(directives, "include") != nil
+}
+
+// jsonValue formats a response value for a message
+func jsonValue(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,108 @@
+package core_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestCheckResponse(t *testing.T) {
+	schema := mustLoadSchema(t, `
+type Query { user: User! search: [SearchResult!]! node: Node }
+type User implements Node { id: ID! name: String tags: [String!]! }
+type Post implements Node { id: ID! title: String! }
+union SearchResult = User | Post
+interface Node { id: ID! }
+`)
+
+	tests := []struct {
+		name     string
+		document string
+		response string
+		want     []string
+	}{
+		{
+			name:     "matching response",
+			document: `query GetUser { user { id name tags } }`,
+			response: `{"data": {"user": {"id": "1", "name": null, "tags": ["a"]}}}`,
+		},
+		{
+			name:     "null non-null field",
+			document: `query GetUser { user { id name } }`,
+			response: `{"data": {"user": {"id": null, "name": "Ada"}}}`,
+			want:     []string{"'data.user.id' is null, but its type ID! is non-null"},
+		},
+		{
+			name:     "null non-null list item",
+			document: `query GetUser { user { id tags } }`,
+			response: `{"data": {"user": {"id": "1", "tags": ["a", null]}}}`,
+			want:     []string{"'data.user.tags[1]' is null, but its type String! is non-null"},
+		},
+		{
+			name:     "missing field",
+			document: `query GetUser { user { id name } }`,
+			response: `{"data": {"user": {"id": "1"}}}`,
+			want:     []string{"'data.user.name' is missing from the response"},
+		},
+		{
+			name:     "fields skipped by a directive may be missing",
+			document: `query GetUser($full: Boolean!) { user { id name @include(if: $full) } }`,
+			response: `{"data": {"user": {"id": "1"}}}`,
+		},
+		{
+			name:     "selected __typename missing on an abstract type",
+			document: `query Search { search { __typename ... on User { id } ... on Post { title } } }`,
+			response: `{"data": {"search": [{"id": "1"}]}}`,
+			want:     []string{"'data.search[0].__typename' is missing from the response"},
+		},
+		{
+			name:     "fragments on abstract types without __typename may not apply",
+			document: `query Search { search { ... on User { id } ... on Post { title } } }`,
+			response: `{"data": {"search": [{"id": "1"}, {"title": "Hi"}]}}`,
+		},
+		{
+			name:     "__typename makes the fields of matching fragments required",
+			document: `query Search { search { __typename ... on User { id } ... on Post { title } } }`,
+			response: `{"data": {"search": [{"__typename": "Post"}]}}`,
+			want:     []string{"'data.search[0].title' is missing from the response"},
+		},
+		{
+			name:     "abstract type with an impossible __typename",
+			document: `query Search { search { __typename ... on User { id } } }`,
+			response: `{"data": {"search": [{"__typename": "Comment", "id": "1"}]}}`,
+			want:     []string{"'data.search[0].__typename' is 'Comment', which is not a possible type of SearchResult"},
+		},
+		{
+			name:     "abstract type with __typename",
+			document: `query Search { search { __typename ... on User { id } ... on Post { title } } node { __typename id } }`,
+			response: `{"data": {"search": [{"__typename": "User", "id": "1"}, {"__typename": "Post", "title": "Hi"}], "node": {"__typename": "Post", "id": "2"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.response), &response); err != nil {
+				t.Fatalf("invalid response fixture: %v", err)
+			}
+
+			result, err := core.CheckResponse(schema, mustLoadDocuments(t, tt.document)[0], "", response)
+			if err != nil {
+				t.Fatalf("CheckResponse() error = %v", err)
+			}
+
+			var got []string
+			for _, diagnostic := range result.Diagnostics {
+				got = append(got, diagnostic.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diagnostics = %q, want %q", got, tt.want)
+			}
+			if result.IsValid != (len(tt.want) == 0) {
+				t.Errorf("IsValid = %v with diagnostics %+v", result.IsValid, result.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// SeverityOff disables a rule when used in a rule configuration
+const SeverityOff Severity = "off"
+
+// Rule represents a named document validation rule that runs alongside the
+// rules of the GraphQL specification
+type Rule struct {
+	ID          string
+	Description string
+	// DefaultSeverity is used when a rule is enabled without a severity
+	DefaultSeverity Severity
+	// EnabledByDefault rules run unless they are configured as "off"
+	EnabledByDefault bool
+	// Create returns the visitor that checks a document. The visitor runs with
+	// type information, available through the context.
+	Create func(ctx *RuleContext) *visitor.VisitorOptions
+}
+
+// RuleContext gives a rule access to the document being validated
+type RuleContext struct {
+	Schema   *graphql.Schema
+	Document *ast.Document
+	TypeInfo *graphql.TypeInfo
+	Options  *ValidateOptions
+
+	rule        *Rule
+	severity    Severity
+	fragments   *fragmentIndex
+	diagnostics []Diagnostic
+}
+
+// Report records a diagnostic for a node of the document. Nodes of fragments merged
+// in from other documents are reported with those documents instead.
+func (ctx *RuleContext) Report(node ast.Node, message, suggestion string) {
+	if !isLocalNode(node, ctx.Document) {
+		return
+	}
+	diagnostic := Diagnostic{
+		RuleID:     ctx.rule.ID,
+		Severity:   ctx.severity,
+		Message:    message,
+		Range:      nodeRange(node),
+		Suggestion: suggestion,
+	}
+	if diagnostic.Range != nil {
+		diagnostic.Operation = operationAt(ctx.Document, diagnostic.Range.Start)
+	}
+	ctx.diagnostics = append(ctx.diagnostics, diagnostic)
+}
+
+// ruleRegistry holds the available rules by ID
+var ruleRegistry = make(map[string]*Rule)
+
+// RegisterRule makes a rule available to ValidateOptions.CustomRules
+func RegisterRule(rule *Rule) {
+	ruleRegistry[rule.ID] = rule
+}
+
+// Rules returns the registered rules sorted by ID
+func Rules() []*Rule {
+	rules := make([]*Rule, 0, len(ruleRegistry))
+	for _, rule := range ruleRegistry {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// ruleConfig is a rule enabled at a severity
+type ruleConfig struct {
+	rule     *Rule
+	severity Severity
+}
+
+// resolveRules parses rule settings such as "no-introspection" or
+// "max-root-fields=warning" into the rules to run, including those enabled by
+// default that aren't turned off
+func resolveRules(settings []string) ([]ruleConfig, error) {
+	severities := make(map[string]Severity)
+	for _, setting := range settings {
+		id, severity := setting, Severity("")
+		if i := strings.Index(setting, "="); i >= 0 {
+			id, severity = setting[:i], Severity(strings.ToLower(setting[i+1:]))
+		}
+		id = strings.TrimSpace(id)
+
+		rule, exists := ruleRegistry[id]
+		if !exists {
+			return nil, fmt.Errorf("unknown validation rule %q", id)
+		}
+		switch severity {
+		case "":
+			severity = rule.DefaultSeverity
+		case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		default:
+			return nil, fmt.Errorf("invalid severity %q for rule %q", severity, id)
+		}
+		severities[id] = severity
+	}
+
+	var configs []ruleConfig
+	for _, rule := range Rules() {
+		severity, configured := severities[rule.ID]
+		if !configured {
+			if !rule.EnabledByDefault {
+				continue
+			}
+			severity = rule.DefaultSeverity
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		configs = append(configs, ruleConfig{rule: rule, severity: severity})
+	}
+
+	return configs, nil
+}
+
+// runRules runs the configured rules over a document in a single pass
+func runRules(schema *graphql.Schema, docAST *ast.Document, fragments *fragmentIndex, configs []ruleConfig, options *ValidateOptions) []Diagnostic {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: schema})
+	contexts := make([]*RuleContext, 0, len(configs))
+	visitors := make([]*visitor.VisitorOptions, 0, len(configs))
+
+	for _, config := range configs {
+		ctx := &RuleContext{
+			Schema:    schema,
+			Document:  docAST,
+			TypeInfo:  typeInfo,
+			Options:   options,
+			rule:      config.rule,
+			severity:  config.severity,
+			fragments: fragments,
+		}
+		contexts = append(contexts, ctx)
+		visitors = append(visitors, config.rule.Create(ctx))
+	}
+
+	visitor.Visit(docAST, visitor.VisitWithTypeInfo(typeInfo, visitor.VisitInParallel(visitors...)), nil)
+
+	var diagnostics []Diagnostic
+	for _, ctx := range contexts {
+		diagnostics = append(diagnostics, ctx.diagnostics...)
+	}
+	return diagnostics
+}
+
+func init() {
+	RegisterRule(&Rule{
+		ID:              "require-operation-name",
+		Description:     "Operations must have a PascalCase name",
+		DefaultSeverity: SeverityError,
+		Create: func(ctx *RuleContext) *visitor.VisitorOptions {
+			return &visitor.VisitorOptions{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					opDef, ok := p.Node.(*ast.OperationDefinition)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					if opDef.Name == nil {
+						ctx.Report(opDef, fmt.Sprintf("Anonymous %s operation must be named", opDef.Operation), suggestOperationName(opDef))
+					} else if name := opDef.Name.Value; !isPascalCase(name) {
+						ctx.Report(opDef.Name, fmt.Sprintf("Operation name '%s' should be PascalCase", name),
+							fmt.Sprintf("Rename it to '%s'", toPascalCase(name)))
+					}
+					return visitor.ActionSkip, nil
+				},
+			}
+		},
+	})
+
+	RegisterRule(&Rule{
+		ID:              "no-anonymous-operations",
+		Description:     "Operations must be named so they can be identified in logs and metrics",
+		DefaultSeverity: SeverityError,
+		Create: func(ctx *RuleContext) *visitor.VisitorOptions {
+			return &visitor.VisitorOptions{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					opDef, ok := p.Node.(*ast.OperationDefinition)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					if opDef.Name == nil {
+						ctx.Report(opDef, fmt.Sprintf("Anonymous %s operation", opDef.Operation), suggestOperationName(opDef))
+					}
+					return visitor.ActionSkip, nil
+				},
+			}
+		},
+	})
+
+	RegisterRule(&Rule{
+		ID:              "no-introspection",
+		Description:     "Operations must not query the introspection fields __schema and __type",
+		DefaultSeverity: SeverityError,
+		Create: func(ctx *RuleContext) *visitor.VisitorOptions {
+			return &visitor.VisitorOptions{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if field, ok := p.Node.(*ast.Field); ok {
+						if name := field.Name.Value; name == "__schema" || name == "__type" {
+							ctx.Report(field, fmt.Sprintf("Introspection field '%s' is not allowed", name), "")
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			}
+		},
+	})
+
+	RegisterRule(&Rule{
+		ID:              "max-root-fields",
+		Description:     "Operations must not select more root fields than ValidateOptions.MaxRootFields",
+		DefaultSeverity: SeverityError,
+		Create: func(ctx *RuleContext) *visitor.VisitorOptions {
+			fragments := fragmentDefinitions(ctx.Document)
+			return &visitor.VisitorOptions{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					opDef, ok := p.Node.(*ast.OperationDefinition)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					limit := ctx.Options.MaxRootFields
+					if count := countRootFields(opDef.SelectionSet, fragments, make(map[string]bool)); limit > 0 && count > limit {
+						ctx.Report(opDef, fmt.Sprintf("Operation '%s' selects %d root fields, exceeding maximum of %d",
+							getOperationName(opDef), count, limit), "Split the operation into several smaller ones")
+					}
+					return visitor.ActionSkip, nil
+				},
+			}
+		},
+	})
+
+	RegisterRule(&Rule{
+		ID:               "no-unused-fragments",
+		Description:      "Fragments must be spread by at least one operation in the document set",
+		DefaultSeverity:  SeverityError,
+		EnabledByDefault: true,
+		Create: func(ctx *RuleContext) *visitor.VisitorOptions {
+			used := ctx.fragments.used
+			return &visitor.VisitorOptions{
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					fragment, ok := p.Node.(*ast.FragmentDefinition)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					if !used[fragment.Name.Value] {
+						ctx.Report(fragment, fmt.Sprintf("Fragment '%s' is never used", fragment.Name.Value), "Remove the fragment")
+					}
+					return visitor.ActionSkip, nil
+				},
+			}
+		},
+	})
+}
+
+// countRootFields counts the fields an operation selects on its root type,
+// including those selected through fragments
+func countRootFields(selectionSet *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, spreading map[string]bool) int {
+	if selectionSet == nil {
+		return 0
+	}
+
+	count := 0
+	for _, selection := range selectionSet.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if sel.Name.Value != "__typename" {
+				count++
+			}
+		case *ast.InlineFragment:
+			count += countRootFields(sel.SelectionSet, fragments, spreading)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			if fragment, exists := fragments[name]; exists && !spreading[name] {
+				spreading[name] = true
+				count += countRootFields(fragment.SelectionSet, fragments, spreading)
+				delete(spreading, name)
+			}
+		}
+	}
+	return count
+}
+
+// suggestOperationName suggests a name for an anonymous operation from its first root field
+func suggestOperationName(opDef *ast.OperationDefinition) string {
+	if opDef.SelectionSet == nil {
+		return ""
+	}
+	for _, selection := range opDef.SelectionSet.Selections {
+		if field, ok := selection.(*ast.Field); ok {
+			return fmt.Sprintf("Name it, e.g. '%s %s'", opDef.Operation, toPascalCase(field.Name.Value))
+		}
+	}
+	return ""
+}
+
+// isPascalCase reports whether a name starts with an upper-case letter and has no underscores
+func isPascalCase(name string) bool {
+	if name == "" {
+		return false
+	}
+	return unicode.IsUpper([]rune(name)[0]) && !strings.Contains(name, "_")
+}
+
+// toPascalCase converts camelCase and snake_case names to PascalCase
+func toPascalCase(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' || r == '-' {
+			upper = true
+			continue
+		}
+		if upper {
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
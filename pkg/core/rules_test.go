@@ -0,0 +1,76 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestOperationNameRules(t *testing.T) {
+	schema := mustLoadSchema(t, `type Query { users: [User] } type User { id: ID }`)
+
+	tests := []struct {
+		name     string
+		document string
+		rules    []string
+		want     map[string]int
+	}{
+		{
+			name:     "anonymous operation",
+			document: `{ users { id } }`,
+			want:     map[string]int{"require-operation-name": 1, "no-anonymous-operations": 1},
+		},
+		{
+			name:     "anonymous operation with require-operation-name alone",
+			document: `query { users { id } }`,
+			rules:    []string{"require-operation-name"},
+			want:     map[string]int{"require-operation-name": 1},
+		},
+		{
+			name:     "operation name that is not PascalCase with no-anonymous-operations alone",
+			document: `query get_users { users { id } }`,
+			rules:    []string{"no-anonymous-operations"},
+			want:     map[string]int{},
+		},
+		{
+			name:     "operation name that is not PascalCase",
+			document: `query get_users { users { id } }`,
+			want:     map[string]int{"require-operation-name": 1},
+		},
+		{
+			name:     "PascalCase operation name",
+			document: `query GetUsers { users { id } }`,
+			want:     map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if rules == nil {
+				rules = []string{"require-operation-name", "no-anonymous-operations"}
+			}
+			results, err := core.ValidateDocuments(schema, mustLoadDocuments(t, tt.document), &core.ValidateOptions{
+				CustomRules: rules,
+			})
+			if err != nil {
+				t.Fatalf("ValidateDocuments() error = %v", err)
+			}
+
+			got := make(map[string]int)
+			for _, result := range results {
+				for _, diagnostic := range result.Diagnostics {
+					got[diagnostic.RuleID]++
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("diagnostics by rule = %v, want %v", got, tt.want)
+			}
+			for ruleID, count := range tt.want {
+				if got[ruleID] != count {
+					t.Errorf("diagnostics by rule = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// parseSchemaSDL parses the SDL a schema was loaded from
+func parseSchemaSDL(schema *Schema) (*ast.Document, error) {
+	if schema == nil || schema.SDL == "" {
+		return nil, fmt.Errorf("schema SDL is not available")
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: schema.SDL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema SDL: %w", err)
+	}
+	return doc, nil
+}
+
+// definitionName returns the name of a type, directive or schema definition
+func definitionName(def ast.Node) string {
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		return def.Name.Value
+	case *ast.InterfaceDefinition:
+		return def.Name.Value
+	case *ast.UnionDefinition:
+		return def.Name.Value
+	case *ast.EnumDefinition:
+		return def.Name.Value
+	case *ast.InputObjectDefinition:
+		return def.Name.Value
+	case *ast.ScalarDefinition:
+		return def.Name.Value
+	case *ast.TypeExtensionDefinition:
+		if def.Definition != nil {
+			return def.Definition.Name.Value
+		}
+	case *ast.DirectiveDefinition:
+		return "@" + def.Name.Value
+	case *ast.SchemaDefinition:
+		return "schema"
+	}
+	return ""
+}
+
+// printSDL prints SDL definitions separated by blank lines
+func printSDL(defs []ast.Node) string {
+	printed := make([]string, 0, len(defs))
+	for _, def := range defs {
+		printed = append(printed, printDefinition(def))
+	}
+	return strings.Join(printed, "\n\n") + "\n"
+}
+
+// printDefinition prints a single SDL definition
+func printDefinition(def ast.Node) string {
+	var sb strings.Builder
+
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		sb.WriteString(printDescription(def.Description, ""))
+		sb.WriteString("type " + def.Name.Value)
+		sb.WriteString(printImplements(def.Interfaces))
+		sb.WriteString(printDirectives(def.Directives))
+		sb.WriteString(printFieldBlock(def.Fields))
+	case *ast.TypeExtensionDefinition:
+		if def.Definition != nil {
+			sb.WriteString("extend type " + def.Definition.Name.Value)
+			sb.WriteString(printImplements(def.Definition.Interfaces))
+			sb.WriteString(printDirectives(def.Definition.Directives))
+			sb.WriteString(printFieldBlock(def.Definition.Fields))
+		}
+	case *ast.InterfaceDefinition:
+		sb.WriteString(printDescription(def.Description, ""))
+		sb.WriteString("interface " + def.Name.Value)
+		sb.WriteString(printDirectives(def.Directives))
+		sb.WriteString(printFieldBlock(def.Fields))
+	case *ast.UnionDefinition:
+		sb.WriteString(printDescription(def.Description, ""))
+		sb.WriteString("union " + def.Name.Value)
+		sb.WriteString(printDirectives(def.Directives))
+		if len(def.Types) > 0 {
+			members := make([]string, 0, len(def.Types))
+			for _, member := range def.Types {
+				members = append(members, member.Name.Value)
+			}
+			sb.WriteString(" = " + strings.Join(members, " | "))
+		}
+	case *ast.EnumDefinition:
+		sb.WriteString(printDescription(def.Description, ""))
+		sb.WriteString("enum " + def.Name.Value)
+		sb.WriteString(printDirectives(def.Directives))
+		lines := make([]string, 0, len(def.Values))
+		for _, value := range def.Values {
+			lines = append(lines, printEnumValue(value))
+		}
+		sb.WriteString(printBlock(lines))
+	case *ast.InputObjectDefinition:
+		sb.WriteString(printDescription(def.Description, ""))
+		sb.WriteString("input " + def.Name.Value)
+		sb.WriteString(printDirectives(def.Directives))
+		lines := make([]string, 0, len(def.Fields))
+		for _, field := range def.Fields {
+			lines = append(lines, printInputValue(field, "  "))
+		}
+		sb.WriteString(printBlock(lines))
+	case *ast.ScalarDefinition:
+		sb.WriteString(printDescription(def.Description, ""))
+		sb.WriteString("scalar " + def.Name.Value)
+		sb.WriteString(printDirectives(def.Directives))
+	default:
+		// Schema and directive definitions have no nested members worth custom formatting
+		sb.WriteString(fmt.Sprint(printer.Print(def)))
+	}
+
+	return sb.String()
+}
+
+// printFieldBlock prints the braced field list of an object or interface type
+func printFieldBlock(fields []*ast.FieldDefinition) string {
+	lines := make([]string, 0, len(fields))
+	for _, field := range fields {
+		lines = append(lines, printField(field))
+	}
+	return printBlock(lines)
+}
+
+// printBlock wraps member lines in braces
+func printBlock(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return " {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+// printField prints a field definition, including its description and arguments
+func printField(field *ast.FieldDefinition) string {
+	var sb strings.Builder
+	sb.WriteString(printDescription(field.Description, "  "))
+	sb.WriteString("  " + field.Name.Value)
+	sb.WriteString(printArguments(field.Arguments))
+	sb.WriteString(": " + printNode(field.Type))
+	sb.WriteString(printDirectives(field.Directives))
+	return sb.String()
+}
+
+// printArguments prints field arguments inline, or one per line when any is documented
+func printArguments(args []*ast.InputValueDefinition) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	multiline := false
+	for _, arg := range args {
+		if arg.Description != nil && arg.Description.Value != "" {
+			multiline = true
+			break
+		}
+	}
+
+	printed := make([]string, 0, len(args))
+	if !multiline {
+		for _, arg := range args {
+			printed = append(printed, printInputValue(arg, ""))
+		}
+		return "(" + strings.Join(printed, ", ") + ")"
+	}
+
+	for _, arg := range args {
+		printed = append(printed, printInputValue(arg, "    "))
+	}
+	return "(\n" + strings.Join(printed, "\n") + "\n  )"
+}
+
+// printInputValue prints an argument or input field definition
+func printInputValue(value *ast.InputValueDefinition, indent string) string {
+	var sb strings.Builder
+	sb.WriteString(printDescription(value.Description, indent))
+	sb.WriteString(indent + value.Name.Value + ": " + printNode(value.Type))
+	if value.DefaultValue != nil {
+		sb.WriteString(" = " + printNode(value.DefaultValue))
+	}
+	sb.WriteString(printDirectives(value.Directives))
+	return sb.String()
+}
+
+// printEnumValue prints an enum value definition
+func printEnumValue(value *ast.EnumValueDefinition) string {
+	return printDescription(value.Description, "  ") + "  " + value.Name.Value + printDirectives(value.Directives)
+}
+
+// printImplements prints the implemented interfaces of an object type
+func printImplements(interfaces []*ast.Named) string {
+	if len(interfaces) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(interfaces))
+	for _, iface := range interfaces {
+		names = append(names, iface.Name.Value)
+	}
+	return " implements " + strings.Join(names, " & ")
+}
+
+// printDirectives prints directive usages with a leading space
+func printDirectives(directives []*ast.Directive) string {
+	var sb strings.Builder
+	for _, directive := range directives {
+		sb.WriteString(" " + printNode(directive))
+	}
+	return sb.String()
+}
+
+// printDescription prints a description on its own line(s) at the given indentation
+func printDescription(desc *ast.StringValue, indent string) string {
+	if desc == nil || desc.Value == "" {
+		return ""
+	}
+
+	if !strings.Contains(desc.Value, "\n") && !strings.Contains(desc.Value, `"`) {
+		return indent + `"` + strings.ReplaceAll(desc.Value, `\`, `\\`) + `"` + "\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(indent + `"""` + "\n")
+	for _, line := range strings.Split(desc.Value, "\n") {
+		if line == "" {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(indent + strings.ReplaceAll(line, `"""`, `\"""`) + "\n")
+	}
+	sb.WriteString(indent + `"""` + "\n")
+	return sb.String()
+}
+
+// printNode prints a type reference, value or directive using the graphql-go printer
+func printNode(node ast.Node) string {
+	return fmt.Sprint(printer.Print(node))
+}
+
+// directivesByCoordinate indexes the directives applied to each schema coordinate,
+// e.g. "User", "User.posts", "User.posts(first:)" and "Role.ADMIN"
+func directivesByCoordinate(doc *ast.Document) map[string][]*ast.Directive {
+	index := make(map[string][]*ast.Directive)
+
+	addFields := func(typeName string, fields []*ast.FieldDefinition) {
+		for _, field := range fields {
+			fieldPath := typeName + "." + field.Name.Value
+			index[fieldPath] = append(index[fieldPath], field.Directives...)
+			for _, arg := range field.Arguments {
+				argPath := fmt.Sprintf("%s(%s:)", fieldPath, arg.Name.Value)
+				index[argPath] = append(index[argPath], arg.Directives...)
+			}
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			index[def.Name.Value] = append(index[def.Name.Value], def.Directives...)
+			addFields(def.Name.Value, def.Fields)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				name := def.Definition.Name.Value
+				index[name] = append(index[name], def.Definition.Directives...)
+				addFields(name, def.Definition.Fields)
+			}
+		case *ast.InterfaceDefinition:
+			index[def.Name.Value] = append(index[def.Name.Value], def.Directives...)
+			addFields(def.Name.Value, def.Fields)
+		case *ast.UnionDefinition:
+			index[def.Name.Value] = append(index[def.Name.Value], def.Directives...)
+		case *ast.ScalarDefinition:
+			index[def.Name.Value] = append(index[def.Name.Value], def.Directives...)
+		case *ast.EnumDefinition:
+			index[def.Name.Value] = append(index[def.Name.Value], def.Directives...)
+			for _, value := range def.Values {
+				valuePath := def.Name.Value + "." + value.Name.Value
+				index[valuePath] = append(index[valuePath], value.Directives...)
+			}
+		case *ast.InputObjectDefinition:
+			index[def.Name.Value] = append(index[def.Name.Value], def.Directives...)
+			for _, field := range def.Fields {
+				fieldPath := def.Name.Value + "." + field.Name.Value
+				index[fieldPath] = append(index[fieldPath], field.Directives...)
+			}
+		}
+	}
+
+	return index
+}
+
+// hasDirective reports whether a directive with the given name is in the list
+func hasDirective(directives []*ast.Directive, name string) bool {
+	for _, directive := range directives {
+		if directive.Name != nil && directive.Name.Value == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"sort"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// CoverageThreshold is a minimum coverage for the types matching a pattern: a
+// type-name glob such as "Viewer*", or a directive such as "@internal" or
+// `@tag(name: "public")`
+type CoverageThreshold struct {
+	Pattern  string  `yaml:"pattern" json:"pattern"`
+	Coverage float64 `yaml:"coverage" json:"coverage"`
+}
+
+// ThresholdResult is the outcome of evaluating a coverage threshold
+type ThresholdResult struct {
+	Pattern   string   `json:"pattern"`
+	Threshold float64  `json:"threshold"`
+	Coverage  float64  `json:"coverage"`
+	Covered   int      `json:"covered"`
+	Total     int      `json:"total"`
+	Types     []string `json:"types"`
+	Passed    bool     `json:"passed"`
+}
+
+// EvaluateCoverageThresholds checks each threshold against the combined coverage
+// of the members of the types it matches. Types without members, such as scalars,
+// count as a single member. A threshold matching no types passes.
+func EvaluateCoverageThresholds(schema *Schema, result *CoverageResult, thresholds []CoverageThreshold) []ThresholdResult {
+	var patterns []string
+	for _, threshold := range thresholds {
+		patterns = append(patterns, threshold.Pattern)
+	}
+	var directives map[string][]*ast.Directive
+	if hasDirectivePattern(patterns) {
+		directives = mergedDirectiveIndex(schema)
+	}
+
+	typeNames := make([]string, 0, len(result.Details))
+	for typeName := range result.Details {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	results := make([]ThresholdResult, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		evaluated := ThresholdResult{
+			Pattern:   threshold.Pattern,
+			Threshold: threshold.Coverage,
+			Types:     []string{},
+		}
+		for _, typeName := range typeNames {
+			if !matchesCoordinatePattern(typeName, threshold.Pattern, directives) {
+				continue
+			}
+			evaluated.Types = append(evaluated.Types, typeName)
+			covered, total := typeMemberCounts(result.Details[typeName])
+			if total == 0 {
+				total = 1
+				if result.Details[typeName].Covered {
+					covered = 1
+				}
+			}
+			evaluated.Covered += covered
+			evaluated.Total += total
+		}
+		evaluated.Coverage = coverageRatio(evaluated.Covered, evaluated.Total)
+		evaluated.Passed = evaluated.Total == 0 || evaluated.Coverage >= threshold.Coverage
+		results = append(results, evaluated)
+	}
+	return results
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/lexer"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// lexTokens splits a GraphQL source into lexical tokens. Whitespace, commas and
+// comments are ignored by the lexer and don't produce tokens.
+func lexTokens(body string) ([]lexer.Token, error) {
+	lex := lexer.Lex(source.NewSource(&source.Source{
+		Body: []byte(body),
+		Name: "GraphQL request",
+	}))
+
+	var tokens []lexer.Token
+	for {
+		token, err := lex(0)
+		if err != nil {
+			return nil, err
+		}
+		if token.Kind == lexer.EOF {
+			return tokens, nil
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+// operationTokenCounts counts the tokens a gateway would see for each operation of
+// a document: the tokens of the operation itself plus those of every fragment it
+// spreads, directly or through other fragments.
+func operationTokenCounts(docAST *ast.Document) (map[*ast.OperationDefinition]int, error) {
+	// Definitions may come from different sources when fragments are shared across
+	// documents, so each source is lexed once and tokens are counted by position
+	lexed := make(map[*source.Source][]lexer.Token)
+
+	definitionTokens := func(def ast.Node) (int, error) {
+		loc := def.GetLoc()
+		if loc == nil || loc.Source == nil {
+			// Definitions built in code have no source text, so count the printed form instead
+			tokens, err := lexTokens(fmt.Sprint(printer.Print(def)))
+			return len(tokens), err
+		}
+
+		tokens, exists := lexed[loc.Source]
+		if !exists {
+			var err error
+			if tokens, err = lexTokens(string(loc.Source.Body)); err != nil {
+				return 0, err
+			}
+			lexed[loc.Source] = tokens
+		}
+
+		count := 0
+		for _, token := range tokens {
+			if token.Start >= loc.Start && token.End <= loc.End {
+				count++
+			}
+		}
+		return count, nil
+	}
+
+	fragments := fragmentDefinitions(docAST)
+	fragmentTokens := make(map[string]int, len(fragments))
+	for name, fragment := range fragments {
+		count, err := definitionTokens(fragment)
+		if err != nil {
+			return nil, err
+		}
+		fragmentTokens[name] = count
+	}
+
+	counts := make(map[*ast.OperationDefinition]int)
+	for _, def := range docAST.Definitions {
+		opDef, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		count, err := definitionTokens(opDef)
+		if err != nil {
+			return nil, err
+		}
+		for name := range usedFragments(opDef.SelectionSet, fragments) {
+			count += fragmentTokens[name]
+		}
+		counts[opDef] = count
+	}
+
+	return counts, nil
+}
+
+// usedFragments returns the names of the fragments a selection set spreads,
+// directly or through other fragments
+func usedFragments(selectionSet *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition) map[string]bool {
+	used := make(map[string]bool)
+
+	var collect func(selectionSet *ast.SelectionSet)
+	collect = func(selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch sel := selection.(type) {
+			case *ast.Field:
+				collect(sel.SelectionSet)
+			case *ast.InlineFragment:
+				collect(sel.SelectionSet)
+			case *ast.FragmentSpread:
+				name := sel.Name.Value
+				if used[name] {
+					continue
+				}
+				used[name] = true
+				if fragment, exists := fragments[name]; exists {
+					collect(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+
+	collect(selectionSet)
+	return used
+}
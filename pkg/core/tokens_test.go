@@ -0,0 +1,118 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestOperationTokenCounts(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  func(t *testing.T) *ast.Document
+		want map[string]int
+	}{
+		{
+			name: "parsed document",
+			doc: func(t *testing.T) *ast.Document {
+				return mustParse(t, `query GetUser { user(id: 1) { name } }`)
+			},
+			// query GetUser { user ( id : 1 ) { name } }
+			want: map[string]int{"GetUser": 13},
+		},
+		{
+			name: "spread fragments count toward each operation",
+			doc: func(t *testing.T) *ast.Document {
+				return mustParse(t, `
+query A { user { ...UserFields } }
+query B { viewer { id } }
+fragment UserFields on User { id name }
+`)
+			},
+			// query A { user { ... UserFields } } plus fragment UserFields on User { id name }
+			want: map[string]int{"A": 9 + 8, "B": 8},
+		},
+		{
+			name: "document built in code without source locations",
+			doc: func(t *testing.T) *ast.Document {
+				return ast.NewDocument(&ast.Document{
+					Definitions: []ast.Node{
+						ast.NewOperationDefinition(&ast.OperationDefinition{
+							Operation: ast.OperationTypeQuery,
+							Name:      ast.NewName(&ast.Name{Value: "Ping"}),
+							SelectionSet: ast.NewSelectionSet(&ast.SelectionSet{
+								Selections: []ast.Selection{
+									ast.NewField(&ast.Field{Name: ast.NewName(&ast.Name{Value: "ping"})}),
+								},
+							}),
+						}),
+					},
+				})
+			},
+			// query Ping { ping }
+			want: map[string]int{"Ping": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docAST := tt.doc(t)
+			counts, err := operationTokenCounts(docAST)
+			if err != nil {
+				t.Fatalf("operationTokenCounts() error = %v", err)
+			}
+
+			// Counts are keyed by the document's own operations
+			for _, def := range docAST.Definitions {
+				opDef, ok := def.(*ast.OperationDefinition)
+				if !ok {
+					continue
+				}
+				name := getOperationName(opDef)
+				if got := counts[opDef]; got != tt.want[name] {
+					t.Errorf("tokens of %s = %d, want %d", name, got, tt.want[name])
+				}
+			}
+		})
+	}
+}
+
+func TestMaxTokensWithoutContent(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+
+	// Documents built in code carry an AST but no Content
+	doc := Document{Source: "code", AST: mustParse(t, `query Hello { hello hello hello }`)}
+	if got := len(validateTokenCount(doc.AST, 5)); got != 1 {
+		t.Errorf("validateTokenCount() reported %d problems, want 1", got)
+	}
+
+	results, err := ValidateDocuments(&Schema{Schema: &schema}, []Document{doc}, &ValidateOptions{MaxTokens: 5})
+	if err != nil {
+		t.Fatalf("ValidateDocuments() error = %v", err)
+	}
+	if len(results) != 1 || results[0].IsValid {
+		t.Errorf("ValidateDocuments() = %+v, want the document to exceed --max-tokens", results)
+	}
+}
+
+// mustParse parses a GraphQL document, failing the test on syntax errors
+func mustParse(t *testing.T, content string) *ast.Document {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: content})
+	if err != nil {
+		t.Fatalf("failed to parse document: %v", err)
+	}
+	return doc
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// OperationTraffic represents the requests a gateway served for an operation,
+// identified by its name or by the hash of its printed source (see operationHash)
+type OperationTraffic struct {
+	OperationName string    `json:"operationName,omitempty"`
+	Hash          string    `json:"hash,omitempty"`
+	Count         int64     `json:"count"`
+	Timestamp     time.Time `json:"timestamp,omitempty"`
+}
+
+// trafficWeights sums the traffic of each operation name and operation hash
+type trafficWeights struct {
+	byName map[string]int64
+	byHash map[string]int64
+}
+
+// newTrafficWeights sums traffic records, skipping those recorded before since.
+// Records without a timestamp always count.
+func newTrafficWeights(traffic []OperationTraffic, since time.Time) *trafficWeights {
+	weights := &trafficWeights{
+		byName: make(map[string]int64),
+		byHash: make(map[string]int64),
+	}
+	for _, record := range traffic {
+		if !since.IsZero() && !record.Timestamp.IsZero() && record.Timestamp.Before(since) {
+			continue
+		}
+		switch {
+		case record.OperationName != "":
+			weights.byName[record.OperationName] += record.Count
+		case record.Hash != "":
+			weights.byHash[normalizeHash(record.Hash)] += record.Count
+		}
+	}
+	return weights
+}
+
+// weight returns the requests served for an operation, by name and by the hash
+// of its printed source
+func (t *trafficWeights) weight(opDef *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition) int64 {
+	var weight int64
+	if opDef.Name != nil {
+		weight += t.byName[opDef.Name.Value]
+	}
+	if len(t.byHash) > 0 {
+		weight += t.byHash[operationHash(opDef, fragments)]
+	}
+	return weight
+}
+
+// operationHash returns the hex SHA-256 of an operation's printed source: the
+// operation as printed by graphql-go, followed by the fragments it spreads in
+// name order, separated by blank lines. Formatting, comments and other
+// operations of the same file don't change it.
+func operationHash(opDef *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition) string {
+	var names []string
+	for name := range usedFragments(opDef.SelectionSet, fragments) {
+		if fragments[name] != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	definitions := []ast.Node{opDef}
+	for _, name := range names {
+		definitions = append(definitions, fragments[name])
+	}
+	printed := fmt.Sprint(printer.Print(ast.NewDocument(&ast.Document{Definitions: definitions})))
+
+	hash := sha256.Sum256([]byte(printed))
+	return hex.EncodeToString(hash[:])
+}
+
+// normalizeHash strips an algorithm prefix such as "sha256:" and lowercases a hash
+func normalizeHash(hash string) string {
+	if i := strings.Index(hash, ":"); i >= 0 {
+		hash = hash[i+1:]
+	}
+	return strings.ToLower(hash)
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+func TestOperationHash(t *testing.T) {
+	printed := "query Home {\n  user {\n    ...UserFields\n  }\n}\n\nfragment UserFields on User {\n  id\n}\n"
+	sum := sha256.Sum256([]byte(printed))
+	want := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{
+			name:     "printed operation with its fragments",
+			document: `query Home { user { ...UserFields } } fragment UserFields on User { id }`,
+		},
+		{
+			name: "formatting, comments and other definitions don't matter",
+			document: `
+# The home page
+fragment Unused on User { name }
+query Other { ping }
+fragment UserFields on User {
+  id
+}
+query Home {
+  user { ...UserFields }
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docAST := mustParse(t, tt.document)
+			var home *ast.OperationDefinition
+			for _, def := range docAST.Definitions {
+				if opDef, ok := def.(*ast.OperationDefinition); ok && opDef.Name.Value == "Home" {
+					home = opDef
+				}
+			}
+			if got := operationHash(home, fragmentDefinitions(docAST)); got != want {
+				t.Errorf("operationHash() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestTrafficWeightsByHash(t *testing.T) {
+	docAST := mustParse(t, `query Home { ping } query Other { ping }`)
+	home := docAST.Definitions[0].(*ast.OperationDefinition)
+	other := docAST.Definitions[1].(*ast.OperationDefinition)
+	fragments := fragmentDefinitions(docAST)
+
+	weights := newTrafficWeights([]OperationTraffic{
+		{Hash: "SHA256:" + operationHash(home, fragments), Count: 5},
+		{OperationName: "Home", Count: 2},
+	}, time.Time{})
+
+	if got := weights.weight(home, fragments); got != 7 {
+		t.Errorf("weight(Home) = %d, want 7", got)
+	}
+	if got := weights.weight(other, fragments); got != 0 {
+		t.Errorf("weight(Other) = %d, want 0", got)
+	}
+}
@@ -48,8 +48,9 @@ type Document struct {
 
 // ValidationResult represents the result of document validation
 type ValidationResult struct {
-	IsValid bool     `json:"isValid"`
-	Errors  []string `json:"errors,omitempty"`
+	Source      string       `json:"source"`
+	IsValid     bool         `json:"isValid"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
 }
 
 // CoverageResult represents schema coverage analysis
@@ -59,7 +60,53 @@ type CoverageResult struct {
 	TotalTypes   int                      `json:"totalTypes"`
 	FieldsCovered int                     `json:"fieldsCovered"`
 	TotalFields   int                     `json:"totalFields"`
+	ArgumentsCovered   int                `json:"argumentsCovered"`
+	TotalArguments     int                `json:"totalArguments"`
+	EnumValuesCovered  int                `json:"enumValuesCovered"`
+	TotalEnumValues    int                `json:"totalEnumValues"`
+	InputFieldsCovered int                `json:"inputFieldsCovered"`
+	TotalInputFields   int                `json:"totalInputFields"`
 	Details      map[string]TypeCoverage  `json:"details"`
+	// Usages lists the operations using each covered coordinate, such as User.email
+	Usages       map[string][]CoordinateUsage `json:"usages,omitempty"`
+	// Traffic statistics, when coverage is weighted by operation traffic. TotalHits
+	// sums the requests selecting each field.
+	TotalHits         int64    `json:"totalHits,omitempty"`
+	FieldsWithTraffic int      `json:"fieldsWithTraffic,omitempty"`
+	ZeroTraffic       []string `json:"zeroTraffic,omitempty"`
+	// AbstractTypes records the possible types handled at each field returning
+	// an interface or union
+	AbstractTypes     []AbstractCoverage `json:"abstractTypes,omitempty"`
+}
+
+// AbstractCoverage records which possible types of an interface or union the
+// fragments under a field handle, across all documents
+type AbstractCoverage struct {
+	// Position is the field returning the abstract type, e.g. Query.search
+	Position       string              `json:"position"`
+	Type           string              `json:"type"`
+	PossibleTypes  []string            `json:"possibleTypes"`
+	HandledTypes   []string            `json:"handledTypes"`
+	UnhandledTypes []string            `json:"unhandledTypes"`
+	Selections     []AbstractSelection `json:"selections"`
+}
+
+// AbstractSelection is one selection of a field returning an abstract type. A
+// selection whose fragments handle no possible type in particular is generic and
+// has no unhandled types.
+type AbstractSelection struct {
+	Source         string   `json:"source"`
+	Operation      string   `json:"operation"`
+	Range          *Range   `json:"range,omitempty"`
+	HandledTypes   []string `json:"handledTypes"`
+	UnhandledTypes []string `json:"unhandledTypes,omitempty"`
+}
+
+// CoordinateUsage represents an operation using a schema coordinate
+type CoordinateUsage struct {
+	Source    string `json:"source"`
+	Operation string `json:"operation"`
+	Range     *Range `json:"range,omitempty"`
 }
 
 // TypeCoverage represents coverage for a specific type
@@ -67,7 +114,16 @@ type TypeCoverage struct {
 	Type         string            `json:"type"`
 	Covered      bool              `json:"covered"`
 	Fields       map[string]bool   `json:"fields"`
+	// Arguments are keyed by field and argument, e.g. "posts(first:)"
+	Arguments    map[string]bool   `json:"arguments,omitempty"`
+	EnumValues   map[string]bool   `json:"enumValues,omitempty"`
+	InputFields  map[string]bool   `json:"inputFields,omitempty"`
+	// UsageCount counts the usages of the type's members, each weighted by the
+	// traffic of its operation when coverage is weighted
 	UsageCount   int               `json:"usageCount"`
+	// Hits weighs each field selection by the traffic of its operation
+	Hits         int64             `json:"hits,omitempty"`
+	FieldHits    map[string]int64  `json:"fieldHits,omitempty"`
 }
 
 // SimilarType represents a similar type found in the schema
@@ -79,18 +135,27 @@ type SimilarType struct {
 
 // DiffOptions represents options for schema comparison
 type DiffOptions struct {
-	IgnoreDescriptions bool     `json:"ignoreDescriptions"`
-	IgnoreDirectives   bool     `json:"ignoreDirectives"`
-	CustomRules        []string `json:"customRules,omitempty"`
+	IgnoreDescriptions          bool     `json:"ignoreDescriptions"`
+	IgnoreDescriptionWhitespace bool     `json:"ignoreDescriptionWhitespace"`
+	IgnoreDescriptionFormatting bool     `json:"ignoreDescriptionFormatting"`
+	IgnoreDirectives            bool     `json:"ignoreDirectives"`
+	DowngradeUnreachable        bool     `json:"downgradeUnreachable"`
+	CustomRules                 []string `json:"customRules,omitempty"`
+	Include                     []string `json:"include,omitempty"`
+	Exclude                     []string `json:"exclude,omitempty"`
 }
 
 // ValidateOptions represents options for document validation
 type ValidateOptions struct {
-	Schema       *Schema   `json:"-"`
-	MaxDepth     int       `json:"maxDepth"`
-	MaxTokens    int       `json:"maxTokens"`
-	MaxAliases   int       `json:"maxAliases"`
-	CustomRules  []string  `json:"customRules,omitempty"`
+	Schema            *Schema  `json:"-"`
+	MaxDepth          int      `json:"maxDepth"`
+	IgnoreDepthFields []string `json:"ignoreDepthFields,omitempty"`
+	MaxTokens         int      `json:"maxTokens"`
+	MaxAliases        int      `json:"maxAliases"`
+	MaxRootFields     int      `json:"maxRootFields"`
+	CustomRules       []string `json:"customRules,omitempty"`
+	// DetectIdenticalOperations flags differently named operations with the same selections
+	DetectIdenticalOperations bool `json:"detectIdenticalOperations"`
 }
 
 // CoverageOptions represents options for coverage analysis
@@ -98,6 +163,14 @@ type CoverageOptions struct {
 	Schema     *Schema     `json:"-"`
 	Documents  []Document  `json:"documents"`
 	Threshold  float64     `json:"threshold"`
+	// Dimensions counted besides types and output fields
+	IncludeArguments   bool `json:"includeArguments"`
+	IncludeEnumValues  bool `json:"includeEnumValues"`
+	IncludeInputFields bool `json:"includeInputFields"`
+	// Traffic weighs coverage by the requests served for each operation;
+	// records before TrafficSince are ignored
+	Traffic      []OperationTraffic `json:"-"`
+	TrafficSince time.Time          `json:"trafficSince,omitempty"`
 }
 
 // InspectorConfig represents the configuration for GraphQL Inspector
@@ -105,8 +178,14 @@ type InspectorConfig struct {
 	SchemaPath     string   `yaml:"schemaPath"`
 	DocumentsPaths []string `yaml:"documentsPaths"`
 	Rules          []string `yaml:"rules"`
+	Diff           struct {
+		Include []string `yaml:"include"`
+		Exclude []string `yaml:"exclude"`
+	} `yaml:"diff"`
 	Thresholds     struct {
 		Coverage float64 `yaml:"coverage"`
 		MaxDepth int     `yaml:"maxDepth"`
+		// Types sets coverage thresholds for the types matching globs or directives
+		Types    []CoverageThreshold `yaml:"types"`
 	} `yaml:"thresholds"`
 } 
\ No newline at end of file
@@ -2,9 +2,11 @@ package core
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/visitor"
@@ -25,176 +27,292 @@ func ValidateDocuments(schema *Schema, documents []Document, options *ValidateOp
 		}
 	}
 
+	rules, err := resolveRules(options.CustomRules)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fragments may be spread in a different document from the one defining them
+	parsed := parseDocuments(documents)
+	fragments := newFragmentIndex(parsed)
+
+	// Fragment and operation names must be unique across the whole set
+	operations := namedOperations(parsed)
+	setDiagnostics := []map[string][]Diagnostic{
+		fragments.duplicateDiagnostics(),
+		duplicateOperationDiagnostics(operations),
+	}
+	if options.DetectIdenticalOperations {
+		setDiagnostics = append(setDiagnostics, identicalOperationDiagnostics(operations, fragments, parsed))
+	}
+
+	// Specification errors are collected for the whole set first, so an error in a
+	// shared fragment is only left to the fragment's own document when it reports it
+	specErrors := make([][]gqlerrors.FormattedError, len(parsed))
+	reported := make(map[specErrorKey]bool)
+	for i, doc := range parsed {
+		if doc.err != nil {
+			continue
+		}
+		docAST := fragments.withExternalFragments(doc.ast)
+		specErrors[i] = graphql.ValidateDocument(schema.Schema, docAST, specifiedRules()).Errors
+		for _, err := range specErrors[i] {
+			if node := errorNode(err); node != nil && isLocalNode(node, docAST) {
+				reported[newSpecErrorKey(node, err)] = true
+			}
+		}
+	}
+
 	results := make([]ValidationResult, 0, len(documents))
 
-	for _, doc := range documents {
-		result := validateDocument(schema, doc, options)
+	for i, doc := range parsed {
+		result := validateDocument(schema, doc, fragments, specErrors[i], reported, options, rules)
+		for _, bySource := range setDiagnostics {
+			result.Diagnostics = append(result.Diagnostics, bySource[doc.doc.Source]...)
+		}
+		result.IsValid = !HasErrors(result.Diagnostics)
 		results = append(results, result)
 	}
 
 	return results, nil
 }
 
-// validateDocument validates a single GraphQL document
-func validateDocument(schema *Schema, doc Document, options *ValidateOptions) ValidationResult {
-	var errors []string
-
-	// Parse the document if AST is not provided
-	var docAST *ast.Document
-	if doc.AST != nil {
-		docAST = doc.AST
-	} else {
-		parsed, err := parser.Parse(parser.ParseParams{
-			Source: doc.Content,
-		})
-		if err != nil {
-			return ValidationResult{
-				IsValid: false,
-				Errors:  []string{fmt.Sprintf("Parse error: %v", err)},
-			}
+// validateDocument validates a single GraphQL document, together with the
+// fragments it uses from other documents. specErrors holds the errors of the
+// specification rules for the document, and reported those its own nodes raised
+// across the set.
+func validateDocument(schema *Schema, parsed parsedDocument, fragments *fragmentIndex, specErrors []gqlerrors.FormattedError, reported map[specErrorKey]bool, options *ValidateOptions, rules []ruleConfig) ValidationResult {
+	var diagnostics []Diagnostic
+	doc := parsed.doc
+
+	if parsed.err != nil {
+		diagnostic := syntaxErrorDiagnostic(parsed.err)
+		diagnostic.Source = doc.Source
+		return ValidationResult{
+			Source:      doc.Source,
+			IsValid:     false,
+			Diagnostics: []Diagnostic{diagnostic},
 		}
-		docAST = parsed
 	}
+	docAST := fragments.withExternalFragments(parsed.ast)
 
 	// Validate document against schema
-	validationResult := graphql.ValidateDocument(schema.Schema, docAST, nil)
-	if validationResult.IsValid == false {
-		for _, err := range validationResult.Errors {
-			errors = append(errors, fmt.Sprintf("Validation error: %v", err))
+	for _, err := range specErrors {
+		diagnostic := Diagnostic{
+			RuleID:   "graphql",
+			Severity: SeverityError,
+			Message:  err.Message,
+			Range:    locationsRange(err.Locations),
 		}
+		if node := errorNode(err); node != nil && !isLocalNode(node, docAST) {
+			// Problems in fragments from other documents are reported with those
+			// documents, unless they depend on the operations spreading them here,
+			// such as undefined variables
+			if reported[newSpecErrorKey(node, err)] {
+				continue
+			}
+			if location := fragments.containing(node); location != nil {
+				diagnostic.Message += fmt.Sprintf(" (in fragment '%s' from %s)", location.definition.Name.Value, location.source)
+				diagnostic.Operation = spreadingOperation(docAST, location.definition.Name.Value, fragments.resolver(docAST))
+			}
+		} else if diagnostic.Range != nil {
+			diagnostic.Operation = operationAt(docAST, diagnostic.Range.Start)
+		}
+		diagnostics = append(diagnostics, diagnostic)
 	}
 
 	// Custom validation rules
-	customErrors := applyCustomValidationRules(docAST, options)
-	errors = append(errors, customErrors...)
+	customDiagnostics := applyCustomValidationRules(docAST, options)
+	diagnostics = append(diagnostics, customDiagnostics...)
+
+	// Variables provided with the document
+	diagnostics = append(diagnostics, validateVariables(schema.Schema, docAST, doc.Variables)...)
+
+	// Rules from the registry
+	diagnostics = append(diagnostics, runRules(schema.Schema, docAST, fragments, rules, options)...)
+
+	for i := range diagnostics {
+		diagnostics[i].Source = doc.Source
+	}
 
 	return ValidationResult{
-		IsValid: len(errors) == 0,
-		Errors:  errors,
+		Source:      doc.Source,
+		IsValid:     !HasErrors(diagnostics),
+		Diagnostics: diagnostics,
 	}
 }
 
+// specifiedRules returns the rules of the GraphQL specification, minus those
+// replaced by configurable rules from the registry
+func specifiedRules() []graphql.ValidationRuleFn {
+	replaced := reflect.ValueOf(graphql.NoUnusedFragmentsRule).Pointer()
+
+	rules := make([]graphql.ValidationRuleFn, 0, len(graphql.SpecifiedRules))
+	for _, rule := range graphql.SpecifiedRules {
+		if reflect.ValueOf(rule).Pointer() != replaced {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
 // applyCustomValidationRules applies custom validation rules to the document
-func applyCustomValidationRules(docAST *ast.Document, options *ValidateOptions) []string {
-	var errors []string
+func applyCustomValidationRules(docAST *ast.Document, options *ValidateOptions) []Diagnostic {
+	var diagnostics []Diagnostic
 
 	// Validate query depth
 	if options.MaxDepth > 0 {
-		if depthErrors := validateQueryDepth(docAST, options.MaxDepth); len(depthErrors) > 0 {
-			errors = append(errors, depthErrors...)
+		if depthDiagnostics := validateQueryDepth(docAST, options.MaxDepth, options.IgnoreDepthFields); len(depthDiagnostics) > 0 {
+			diagnostics = append(diagnostics, depthDiagnostics...)
 		}
 	}
 
 	// Validate token count
 	if options.MaxTokens > 0 {
-		if tokenErrors := validateTokenCount(docAST, options.MaxTokens); len(tokenErrors) > 0 {
-			errors = append(errors, tokenErrors...)
+		if tokenDiagnostics := validateTokenCount(docAST, options.MaxTokens); len(tokenDiagnostics) > 0 {
+			diagnostics = append(diagnostics, tokenDiagnostics...)
 		}
 	}
 
 	// Validate alias count
 	if options.MaxAliases > 0 {
-		if aliasErrors := validateAliasCount(docAST, options.MaxAliases); len(aliasErrors) > 0 {
-			errors = append(errors, aliasErrors...)
+		if aliasDiagnostics := validateAliasCount(docAST, options.MaxAliases); len(aliasDiagnostics) > 0 {
+			diagnostics = append(diagnostics, aliasDiagnostics...)
 		}
 	}
 
-	return errors
+	return diagnostics
 }
 
-// validateQueryDepth validates the depth of GraphQL queries
-func validateQueryDepth(docAST *ast.Document, maxDepth int) []string {
-	var errors []string
-	
-	visitor.Visit(docAST, &visitor.VisitorOptions{
-		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
-			if field, ok := p.Node.(*ast.Field); ok {
-				depth := calculateFieldDepth(field, 0)
-				if depth > maxDepth {
-					errors = append(errors, fmt.Sprintf("Query depth %d exceeds maximum allowed depth of %d", depth, maxDepth))
-				}
-			}
-			return visitor.ActionNoChange, nil
-		},
-	}, nil)
+// validateQueryDepth validates the depth of each operation, reporting the path to its deepest field
+func validateQueryDepth(docAST *ast.Document, maxDepth int, ignoreFields []string) []Diagnostic {
+	var diagnostics []Diagnostic
 
-	return errors
+	calc := newDepthCalculator(docAST, ignoreFields)
+	for _, def := range docAST.Definitions {
+		opDef, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		depth, path, deepest := calc.operationDepth(opDef)
+		if deepest != nil && !isLocalNode(deepest, docAST) {
+			deepest = nil // The deepest field is in a fragment from another document
+		}
+		if depth > maxDepth {
+			diagnostics = append(diagnostics, Diagnostic{
+				RuleID:   "max-depth",
+				Severity: SeverityError,
+				Message: fmt.Sprintf("Operation '%s' has depth %d, exceeding maximum allowed depth of %d (at %s)",
+					getOperationName(opDef), depth, maxDepth, strings.Join(path, ".")),
+				Operation:  getOperationName(opDef),
+				Range:      nodeRangeOr(deepest, opDef),
+				Suggestion: "Split the operation or request nested data in a follow-up query",
+			})
+		}
+	}
+
+	return diagnostics
 }
 
-// calculateFieldDepth calculates the depth of a field
-func calculateFieldDepth(field *ast.Field, currentDepth int) int {
-	if field.SelectionSet == nil {
-		return currentDepth + 1
+// validateTokenCount validates the number of lexical tokens in each operation,
+// counting the fragments it spreads as part of it
+func validateTokenCount(docAST *ast.Document, maxTokens int) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	counts, err := operationTokenCounts(docAST)
+	if err != nil {
+		return []Diagnostic{{
+			RuleID:   "max-tokens",
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("Failed to count tokens: %v", err),
+		}}
 	}
 
-	maxDepth := currentDepth + 1
-	for _, selection := range field.SelectionSet.Selections {
-		switch sel := selection.(type) {
-		case *ast.Field:
-			depth := calculateFieldDepth(sel, currentDepth+1)
-			if depth > maxDepth {
-				maxDepth = depth
-			}
-		case *ast.InlineFragment:
-			for _, fragSelection := range sel.SelectionSet.Selections {
-				if fragField, ok := fragSelection.(*ast.Field); ok {
-					depth := calculateFieldDepth(fragField, currentDepth+1)
-					if depth > maxDepth {
-						maxDepth = depth
-					}
-				}
-			}
+	for _, def := range docAST.Definitions {
+		opDef, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if tokenCount := counts[opDef]; tokenCount > maxTokens {
+			diagnostics = append(diagnostics, Diagnostic{
+				RuleID:    "max-tokens",
+				Severity:  SeverityError,
+				Message:   fmt.Sprintf("Operation '%s' has %d tokens, exceeding maximum of %d", getOperationName(opDef), tokenCount, maxTokens),
+				Operation: getOperationName(opDef),
+				Range:     nodeRange(opDef),
+			})
 		}
 	}
 
-	return maxDepth
+	return diagnostics
 }
 
-// validateTokenCount validates the number of tokens in a GraphQL query
-func validateTokenCount(docAST *ast.Document, maxTokens int) []string {
-	var errors []string
-	tokenCount := 0
+// validateAliasCount validates the number of aliases in each operation,
+// counting the fragments it spreads as part of it
+func validateAliasCount(docAST *ast.Document, maxAliases int) []Diagnostic {
+	var diagnostics []Diagnostic
 
-	visitor.Visit(docAST, &visitor.VisitorOptions{
-		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
-			tokenCount++
-			return visitor.ActionNoChange, nil
-		},
-	}, nil)
-
-	if tokenCount > maxTokens {
-		errors = append(errors, fmt.Sprintf("Query has %d tokens, exceeding maximum of %d", tokenCount, maxTokens))
+	fragments := fragmentDefinitions(docAST)
+	for _, def := range docAST.Definitions {
+		opDef, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		aliasCount := countAliases(opDef.SelectionSet)
+		for name := range usedFragments(opDef.SelectionSet, fragments) {
+			if fragment, exists := fragments[name]; exists {
+				aliasCount += countAliases(fragment.SelectionSet)
+			}
+		}
+		if aliasCount > maxAliases {
+			diagnostics = append(diagnostics, Diagnostic{
+				RuleID:    "max-aliases",
+				Severity:  SeverityError,
+				Message:   fmt.Sprintf("Operation '%s' has %d aliases, exceeding maximum of %d", getOperationName(opDef), aliasCount, maxAliases),
+				Operation: getOperationName(opDef),
+				Range:     nodeRange(opDef),
+			})
+		}
 	}
 
-	return errors
+	return diagnostics
 }
 
-// validateAliasCount validates the number of aliases in a GraphQL query
-func validateAliasCount(docAST *ast.Document, maxAliases int) []string {
-	var errors []string
-	aliasCount := 0
+// countAliases counts the aliased fields of a selection set, without following fragment spreads
+func countAliases(selectionSet *ast.SelectionSet) int {
+	if selectionSet == nil {
+		return 0
+	}
 
-	visitor.Visit(docAST, &visitor.VisitorOptions{
-		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
-			if field, ok := p.Node.(*ast.Field); ok {
-				if field.Alias != nil {
-					aliasCount++
-				}
+	count := 0
+	for _, selection := range selectionSet.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if sel.Alias != nil {
+				count++
 			}
-			return visitor.ActionNoChange, nil
-		},
-	}, nil)
+			count += countAliases(sel.SelectionSet)
+		case *ast.InlineFragment:
+			count += countAliases(sel.SelectionSet)
+		}
+	}
+	return count
+}
 
-	if aliasCount > maxAliases {
-		errors = append(errors, fmt.Sprintf("Query has %d aliases, exceeding maximum of %d", aliasCount, maxAliases))
+// FindDeprecatedUsage finds usage of deprecated fields, arguments, enum values
+// and input fields in documents
+func FindDeprecatedUsage(schema *Schema, documents []Document) ([]Diagnostic, error) {
+	if schema == nil || schema.Schema == nil {
+		return nil, fmt.Errorf("schema is required")
 	}
 
-	return errors
-}
+	// Arguments and input fields don't carry deprecations in graphql-go, so read them from the SDL
+	directives := make(map[string][]*ast.Directive)
+	if sdl, err := parseSchemaSDL(schema); err == nil {
+		directives = directivesByCoordinate(sdl)
+	}
 
-// FindDeprecatedUsage finds deprecated field usage in documents
-func FindDeprecatedUsage(schema *Schema, documents []Document) ([]DeprecatedUsage, error) {
-	var deprecated []DeprecatedUsage
+	var deprecated []Diagnostic
 
 	for _, doc := range documents {
 		// Parse the document if AST is not provided
@@ -211,82 +329,156 @@ func FindDeprecatedUsage(schema *Schema, documents []Document) ([]DeprecatedUsag
 			docAST = parsed
 		}
 
-		// Find deprecated usage
-		visitor.Visit(docAST, &visitor.VisitorOptions{
-			Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
-				if field, ok := p.Node.(*ast.Field); ok {
-					if usage := checkDeprecatedField(schema, field); usage != nil {
-						usage.Source = doc.Source
-						deprecated = append(deprecated, *usage)
-					}
-				}
-				return visitor.ActionNoChange, nil
-			},
-		}, nil)
+		for _, usage := range findDeprecatedUsageInDocument(schema.Schema, directives, docAST) {
+			usage.Source = doc.Source
+			deprecated = append(deprecated, usage)
+		}
 	}
 
 	return deprecated, nil
 }
 
-// DeprecatedUsage represents usage of a deprecated field
-type DeprecatedUsage struct {
-	Source     string `json:"source"`
-	Field      string `json:"field"`
-	Type       string `json:"type"`
-	Reason     string `json:"reason"`
-	Line       int    `json:"line"`
-	Column     int    `json:"column"`
-}
-
-// checkDeprecatedField checks if a field is deprecated
-func checkDeprecatedField(schema *Schema, field *ast.Field) *DeprecatedUsage {
-	// This is a simplified implementation
-	// In a real implementation, you would need to traverse the schema
-	// and check for deprecated fields based on the field path
-	
-	// For now, we'll just check if the field name contains "deprecated"
-	if strings.Contains(strings.ToLower(field.Name.Value), "deprecated") {
-		return &DeprecatedUsage{
-			Field:  field.Name.Value,
-			Type:   "FIELD",
-			Reason: "Field is deprecated",
-			Line:   field.Loc.Start,
-			Column: field.Loc.End,
+// findDeprecatedUsageInDocument walks a document with type information, reporting
+// each deprecated field, argument, enum value and input field it uses
+func findDeprecatedUsageInDocument(schema *graphql.Schema, directives map[string][]*ast.Directive, docAST *ast.Document) []Diagnostic {
+	var usages []Diagnostic
+	typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: schema})
+	operation := ""
+
+	report := func(node ast.Node, coordinate, kind, reason string) {
+		usage := Diagnostic{
+			RuleID:     "no-deprecated",
+			Severity:   SeverityWarning,
+			Message:    fmt.Sprintf("%s '%s' is deprecated", kind, coordinate),
+			Operation:  operation,
+			Coordinate: coordinate,
+			Range:      nodeRange(node),
 		}
+		// Deprecation reasons usually say what to use instead
+		if reason == graphql.DefaultDeprecationReason {
+			usage.Message += ": " + reason
+		} else {
+			usage.Suggestion = reason
+		}
+		usages = append(usages, usage)
 	}
 
-	return nil
+	visitor.Visit(docAST, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+
+			// The enclosing input type has to be read before entering an object field
+			if field, ok := node.(*ast.ObjectField); ok {
+				if input, ok := graphql.GetNamed(typeInfo.InputType()).(*graphql.InputObject); ok {
+					coordinate := input.Name() + "." + field.Name.Value
+					if reason, deprecated := deprecationFromDirectives(directives[coordinate]); deprecated {
+						report(field, coordinate, "Input field", reason)
+					}
+				}
+			}
+
+			typeInfo.Enter(node)
+
+			switch node := node.(type) {
+			case *ast.OperationDefinition:
+				operation = getOperationName(node)
+			case *ast.FragmentDefinition:
+				operation = ""
+			case *ast.Field:
+				fieldDef := typeInfo.FieldDef()
+				parentType := typeInfo.ParentType()
+				if fieldDef != nil && parentType != nil && fieldDef.DeprecationReason != "" {
+					report(node, parentType.Name()+"."+fieldDef.Name, "Field", fieldDef.DeprecationReason)
+				}
+			case *ast.Argument:
+				fieldDef := typeInfo.FieldDef()
+				parentType := typeInfo.ParentType()
+				if typeInfo.Directive() == nil && typeInfo.Argument() != nil && fieldDef != nil && parentType != nil {
+					coordinate := fmt.Sprintf("%s.%s(%s:)", parentType.Name(), fieldDef.Name, node.Name.Value)
+					if reason, deprecated := deprecationFromDirectives(directives[coordinate]); deprecated {
+						report(node, coordinate, "Argument", reason)
+					}
+				}
+			case *ast.EnumValue:
+				if enum, ok := graphql.GetNamed(typeInfo.InputType()).(*graphql.Enum); ok {
+					for _, value := range enum.Values() {
+						if value.Name == node.Value && value.DeprecationReason != "" {
+							report(node, enum.Name()+"."+value.Name, "Enum value", value.DeprecationReason)
+						}
+					}
+				}
+			}
+
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if node, ok := p.Node.(ast.Node); ok {
+				typeInfo.Leave(node)
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}, nil)
+
+	return usages
 }
 
-// ValidateOperationComplexity validates the complexity of GraphQL operations
-func ValidateOperationComplexity(schema *Schema, documents []Document, maxComplexity int) ([]ComplexityResult, error) {
+// deprecationFromDirectives returns the reason of an @deprecated directive, if present
+func deprecationFromDirectives(directives []*ast.Directive) (string, bool) {
+	directive := findDirective(directives, "deprecated")
+	if directive == nil {
+		return "", false
+	}
+	if reason, ok := directiveArgument(directive, "reason").(*ast.StringValue); ok {
+		return reason.Value, true
+	}
+	return graphql.DefaultDeprecationReason, true
+}
+
+// ValidateOperationComplexity validates the cost of GraphQL operations, weighing
+// fields by the @cost and @listSize directives of the schema
+func ValidateOperationComplexity(schema *Schema, documents []Document, maxComplexity int, options *CostOptions) ([]ComplexityResult, error) {
+	if schema == nil || schema.Schema == nil {
+		return nil, fmt.Errorf("schema is required")
+	}
+
 	var results []ComplexityResult
 
-	for _, doc := range documents {
-		// Parse the document if AST is not provided
-		var docAST *ast.Document
-		if doc.AST != nil {
-			docAST = doc.AST
-		} else {
-			parsed, err := parser.Parse(parser.ParseParams{
-				Source: doc.Content,
-			})
-			if err != nil {
-				continue // Skip invalid documents
-			}
-			docAST = parsed
+	parsed := parseDocuments(documents)
+	fragments := newFragmentIndex(parsed)
+
+	for _, doc := range parsed {
+		if doc.err != nil {
+			continue // Skip invalid documents
 		}
+		docAST := fragments.withExternalFragments(doc.ast)
 
 		// Calculate complexity for each operation
+		analyzer := newCostAnalyzer(schema, docAST, doc.doc.Variables, options)
 		for _, def := range docAST.Definitions {
 			if opDef, ok := def.(*ast.OperationDefinition); ok {
-				complexity := calculateOperationComplexity(opDef)
-				results = append(results, ComplexityResult{
-					Source:     doc.Source,
+				complexity, breakdown := analyzer.operationCost(opDef)
+				result := ComplexityResult{
+					Source:     doc.doc.Source,
 					Operation:  getOperationName(opDef),
 					Complexity: complexity,
 					IsValid:    complexity <= maxComplexity,
-				})
+					Breakdown:  breakdown,
+				}
+				if !result.IsValid {
+					result.Diagnostic = &Diagnostic{
+						RuleID:     "max-complexity",
+						Severity:   SeverityError,
+						Message:    fmt.Sprintf("Operation '%s' has complexity %d, exceeding maximum of %d", result.Operation, complexity, maxComplexity),
+						Source:     doc.doc.Source,
+						Operation:  result.Operation,
+						Range:      nodeRange(opDef),
+						Suggestion: costSuggestion(breakdown),
+					}
+				}
+				results = append(results, result)
 			}
 		}
 	}
@@ -296,46 +488,25 @@ func ValidateOperationComplexity(schema *Schema, documents []Document, maxComple
 
 // ComplexityResult represents the complexity analysis result
 type ComplexityResult struct {
-	Source     string `json:"source"`
-	Operation  string `json:"operation"`
-	Complexity int    `json:"complexity"`
-	IsValid    bool   `json:"isValid"`
-}
-
-// calculateOperationComplexity calculates the complexity of an operation
-func calculateOperationComplexity(opDef *ast.OperationDefinition) int {
-	// Simple complexity calculation - count the number of fields
-	// In a real implementation, this would be more sophisticated
-	complexity := 0
-	
-	if opDef.SelectionSet != nil {
-		complexity = countSelections(opDef.SelectionSet)
-	}
-	
-	return complexity
+	Source     string      `json:"source"`
+	Operation  string      `json:"operation"`
+	Complexity int         `json:"complexity"`
+	IsValid    bool        `json:"isValid"`
+	Breakdown  []CostNode  `json:"breakdown,omitempty"`
+	Diagnostic *Diagnostic `json:"diagnostic,omitempty"`
 }
 
-// countSelections counts the number of selections in a selection set
-func countSelections(selectionSet *ast.SelectionSet) int {
-	count := 0
-	
-	for _, selection := range selectionSet.Selections {
-		switch sel := selection.(type) {
-		case *ast.Field:
-			count++
-			if sel.SelectionSet != nil {
-				count += countSelections(sel.SelectionSet)
-			}
-		case *ast.InlineFragment:
-			if sel.SelectionSet != nil {
-				count += countSelections(sel.SelectionSet)
-			}
-		case *ast.FragmentSpread:
-			count++
-		}
+// rootType returns the schema type an operation starts from, or nil when the
+// schema doesn't support its operation type
+func rootType(schema *graphql.Schema, opDef *ast.OperationDefinition) *graphql.Object {
+	switch opDef.Operation {
+	case ast.OperationTypeMutation:
+		return schema.MutationType()
+	case ast.OperationTypeSubscription:
+		return schema.SubscriptionType()
+	default:
+		return schema.QueryType()
 	}
-	
-	return count
 }
 
 // getOperationName gets the name of an operation
@@ -343,5 +514,5 @@ func getOperationName(opDef *ast.OperationDefinition) string {
 	if opDef.Name != nil {
 		return opDef.Name.Value
 	}
-	return fmt.Sprintf("Anonymous%s", strings.Title(opDef.Operation))
+	return "Anonymous" + strings.ToUpper(opDef.Operation[:1]) + opDef.Operation[1:]
 } 
\ No newline at end of file
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateAliasCount(t *testing.T) {
+	docAST := mustParse(t, `
+query Few { a: user { id } }
+query Many {
+  a: user { id }
+  ...Aliased
+}
+fragment Aliased on Query { b: user { c: id } }
+`)
+
+	diagnostics := validateAliasCount(docAST, 2)
+	if len(diagnostics) != 1 {
+		t.Fatalf("validateAliasCount() = %+v, want 1 diagnostic", diagnostics)
+	}
+
+	diagnostic := diagnostics[0]
+	if diagnostic.Operation != "Many" {
+		t.Errorf("Operation = %q, want Many", diagnostic.Operation)
+	}
+	if diagnostic.Range == nil || diagnostic.Range.Start.Line != 3 {
+		t.Errorf("Range = %+v, want the operation starting on line 3", diagnostic.Range)
+	}
+	if want := "Operation 'Many' has 3 aliases, exceeding maximum of 2"; diagnostic.Message != want {
+		t.Errorf("Message = %q, want %q", diagnostic.Message, want)
+	}
+}
+
+func TestValidateQueryDepth(t *testing.T) {
+	tests := []struct {
+		name         string
+		document     string
+		maxDepth     int
+		ignoreFields []string
+		want         []string
+	}{
+		{
+			name:     "operations within the limit pass",
+			document: `query Shallow { user { friends { id } } }`,
+			maxDepth: 3,
+		},
+		{
+			name: "the deepest path through fragments is reported",
+			document: `
+query Deep { user { ...Friends } }
+fragment Friends on User { friends { friends { id } } }
+`,
+			maxDepth: 3,
+			want:     []string{"Operation 'Deep' has depth 4, exceeding maximum allowed depth of 3 (at user.friends.friends.id)"},
+		},
+		{
+			name: "ignored fields don't add to the depth",
+			document: `
+query Connection { users { edges { node { friends { id } } } } }
+`,
+			maxDepth:     3,
+			ignoreFields: []string{"edges", "node"},
+		},
+		{
+			name: "ignored fields still count towards other fields",
+			document: `
+query Connection { users { edges { node { friends { edges { node { id } } } } } } }
+`,
+			maxDepth:     2,
+			ignoreFields: []string{"edges", "node"},
+			want:         []string{"Operation 'Connection' has depth 3, exceeding maximum allowed depth of 2 (at users.edges.node.friends.edges.node.id)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, diagnostic := range validateQueryDepth(mustParse(t, tt.document), tt.maxDepth, tt.ignoreFields) {
+				got = append(got, diagnostic.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateQueryDepth() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateQueryDepthMeasuresFragmentsOnce(t *testing.T) {
+	// Each fragment spreads the next one twice, so walking every spread would take
+	// 2^40 steps
+	var sb strings.Builder
+	sb.WriteString("query Wide { ...F0 }\n")
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&sb, "fragment F%d on Query { a: node { ...F%d } b: node { ...F%d } }\n", i, i+1, i+1)
+	}
+	sb.WriteString("fragment F40 on Query { id }\n")
+
+	diagnostics := validateQueryDepth(mustParse(t, sb.String()), 40, nil)
+	if len(diagnostics) != 1 {
+		t.Fatalf("validateQueryDepth() = %+v, want 1 diagnostic", diagnostics)
+	}
+	if want := "Operation 'Wide' has depth 41"; !strings.HasPrefix(diagnostics[0].Message, want) {
+		t.Errorf("Message = %q, want it to start with %q", diagnostics[0].Message, want)
+	}
+}
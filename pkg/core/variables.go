@@ -0,0 +1,278 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// operationVariables returns the variables provided for an operation. A document
+// with a single operation takes the payload as is; a document with several takes
+// an object keyed by operation name.
+func operationVariables(variables map[string]interface{}, docAST *ast.Document, opDef *ast.OperationDefinition) (map[string]interface{}, bool) {
+	if variables == nil {
+		return nil, false
+	}
+	if countOperations(docAST) <= 1 {
+		return variables, true
+	}
+	if opDef.Name == nil {
+		return nil, false
+	}
+	payload, ok := variables[opDef.Name.Value].(map[string]interface{})
+	return payload, ok
+}
+
+// countOperations counts the operations a document defines
+func countOperations(docAST *ast.Document) int {
+	count := 0
+	for _, def := range docAST.Definitions {
+		if _, ok := def.(*ast.OperationDefinition); ok {
+			count++
+		}
+	}
+	return count
+}
+
+// validateVariables checks the variables provided with a document against the
+// variable definitions of its operations and the input types of the schema
+func validateVariables(schema *graphql.Schema, docAST *ast.Document, variables map[string]interface{}) []Diagnostic {
+	if variables == nil {
+		return nil
+	}
+
+	var diagnostics []Diagnostic
+	for _, def := range docAST.Definitions {
+		opDef, ok := def.(*ast.OperationDefinition)
+		if !ok || !isLocalNode(opDef, docAST) {
+			continue
+		}
+		payload, provided := operationVariables(variables, docAST, opDef)
+		if !provided {
+			continue
+		}
+
+		report := func(node ast.Node, severity Severity, message, suggestion string) {
+			diagnostics = append(diagnostics, Diagnostic{
+				RuleID:     "variables",
+				Severity:   severity,
+				Message:    message,
+				Operation:  getOperationName(opDef),
+				Range:      nodeRange(node),
+				Suggestion: suggestion,
+			})
+		}
+
+		declared := make(map[string]bool, len(opDef.VariableDefinitions))
+		for _, varDef := range opDef.VariableDefinitions {
+			name := varDef.Variable.Name.Value
+			declared[name] = true
+
+			inputType, ok := inputTypeFromAST(schema, varDef.Type).(graphql.Input)
+			if !ok {
+				continue // Unknown types are reported by the specification rules
+			}
+
+			value, exists := payload[name]
+			if !exists {
+				if _, nonNull := inputType.(*graphql.NonNull); nonNull && varDef.DefaultValue == nil {
+					report(varDef, SeverityError, fmt.Sprintf("Missing required variable '$%s' of type %s", name, inputType), "")
+				}
+				continue
+			}
+			for _, problem := range coerceVariable(value, inputType, "$"+name) {
+				report(varDef, SeverityError, problem.message, problem.suggestion)
+			}
+		}
+
+		var unknown []string
+		for name := range payload {
+			if !declared[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			node := ast.Node(opDef)
+			if opDef.Name != nil {
+				node = opDef.Name
+			}
+			report(node, SeverityWarning, fmt.Sprintf("Variable '$%s' is not defined by operation '%s'", name, getOperationName(opDef)),
+				"Remove it from the variables, or declare it on the operation")
+		}
+	}
+
+	return diagnostics
+}
+
+// inputTypeFromAST resolves a variable type against the schema, or returns nil
+// when it names an unknown type
+func inputTypeFromAST(schema *graphql.Schema, typeAST ast.Type) graphql.Type {
+	switch t := typeAST.(type) {
+	case *ast.NonNull:
+		if inner := inputTypeFromAST(schema, t.Type); inner != nil {
+			return graphql.NewNonNull(inner)
+		}
+	case *ast.List:
+		if inner := inputTypeFromAST(schema, t.Type); inner != nil {
+			return graphql.NewList(inner)
+		}
+	case *ast.Named:
+		if named := schema.Type(t.Name.Value); named != nil {
+			return named
+		}
+	}
+	return nil
+}
+
+// variableProblem is a value that can't be coerced to its input type
+type variableProblem struct {
+	message    string
+	suggestion string
+}
+
+// coerceVariable checks that a JSON value can be coerced to an input type,
+// returning a problem for each offending part of the value
+func coerceVariable(value interface{}, inputType graphql.Input, path string) []variableProblem {
+	if nonNull, ok := inputType.(*graphql.NonNull); ok {
+		if value == nil {
+			return []variableProblem{{message: fmt.Sprintf("Variable '%s' of type %s must not be null", path, inputType)}}
+		}
+		return coerceVariable(value, nonNull.OfType, path)
+	}
+	if value == nil {
+		return nil
+	}
+
+	switch t := inputType.(type) {
+	case *graphql.List:
+		items, ok := value.([]interface{})
+		if !ok {
+			// A single value is accepted in place of a list of one
+			return coerceVariable(value, t.OfType, path)
+		}
+		var problems []variableProblem
+		for i, item := range items {
+			problems = append(problems, coerceVariable(item, t.OfType, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return problems
+
+	case *graphql.InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return []variableProblem{expectedProblem(path, t.Name(), value)}
+		}
+
+		var problems []variableProblem
+		definitions := t.Fields()
+		names := make([]string, 0, len(definitions))
+		for name := range definitions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := definitions[name]
+			fieldValue, exists := fields[name]
+			if !exists {
+				if _, nonNull := field.Type.(*graphql.NonNull); nonNull && field.DefaultValue == nil {
+					problems = append(problems, variableProblem{
+						message: fmt.Sprintf("Missing required field '%s.%s' of type %s", path, name, field.Type),
+					})
+				}
+				continue
+			}
+			problems = append(problems, coerceVariable(fieldValue, field.Type, path+"."+name)...)
+		}
+
+		var unknown []string
+		for name := range fields {
+			if _, exists := definitions[name]; !exists {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			problems = append(problems, variableProblem{
+				message:    fmt.Sprintf("Unknown field '%s.%s' for input type %s", path, name, t.Name()),
+				suggestion: fmt.Sprintf("Valid fields are: %s", strings.Join(names, ", ")),
+			})
+		}
+		return problems
+
+	case *graphql.Enum:
+		var values []string
+		for _, enumValue := range t.Values() {
+			values = append(values, enumValue.Name)
+		}
+		if name, ok := value.(string); ok && containsString(values, name) {
+			return nil
+		}
+		problem := expectedProblem(path, t.Name(), value)
+		problem.suggestion = fmt.Sprintf("Valid values are: %s", strings.Join(values, ", "))
+		return []variableProblem{problem}
+
+	case *graphql.Scalar:
+		if !isValidScalarValue(t.Name(), value) {
+			return []variableProblem{expectedProblem(path, t.Name(), value)}
+		}
+	}
+
+	return nil
+}
+
+// isValidScalarValue reports whether a JSON value is valid for a built-in scalar.
+// Custom scalars accept any value, since their parsing is up to the server.
+func isValidScalarValue(name string, value interface{}) bool {
+	switch name {
+	case "Int":
+		number, ok := numberValue(value)
+		return ok && number == math.Trunc(number) && number >= math.MinInt32 && number <= math.MaxInt32
+	case "Float":
+		_, ok := numberValue(value)
+		return ok
+	case "String":
+		_, ok := value.(string)
+		return ok
+	case "Boolean":
+		_, ok := value.(bool)
+		return ok
+	case "ID":
+		if _, ok := value.(string); ok {
+			return true
+		}
+		number, ok := numberValue(value)
+		return ok && number == math.Trunc(number)
+	}
+	return true
+}
+
+// numberValue returns a JSON number as a float64, whether it was decoded as a
+// float64, as a Go integer or as a json.Number
+func numberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		number, err := v.Float64()
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// expectedProblem reports a value of the wrong type
+func expectedProblem(path, typeName string, value interface{}) variableProblem {
+	return variableProblem{
+		message: fmt.Sprintf("Variable '%s' expected a value of type %s, got %s", path, typeName, jsonValue(value)),
+	}
+}
@@ -0,0 +1,114 @@
+package core_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+func TestValidateDocumentsVariables(t *testing.T) {
+	schema := mustLoadSchema(t, `
+type Query { users(first: Int, min: Float, after: ID, filter: UserFilter): [User] user(id: ID!): User }
+type User { id: ID }
+input UserFilter { role: Role name: String! }
+enum Role { ADMIN USER }
+`)
+
+	single := `query Users($first: Int, $min: Float, $after: ID, $filter: UserFilter) { users(first: $first, min: $min, after: $after, filter: $filter) { id } }`
+
+	tests := []struct {
+		name      string
+		document  string
+		variables map[string]interface{}
+		want      []string
+	}{
+		{
+			name:      "numbers decoded as float64",
+			document:  single,
+			variables: map[string]interface{}{"first": float64(10), "min": 1.5, "after": float64(3)},
+		},
+		{
+			name:      "numbers decoded as Go integers",
+			document:  single,
+			variables: map[string]interface{}{"first": 10, "min": int32(2), "after": int64(3)},
+		},
+		{
+			name:      "numbers decoded as json.Number",
+			document:  single,
+			variables: map[string]interface{}{"first": json.Number("10"), "min": json.Number("1.5"), "after": json.Number("3")},
+		},
+		{
+			name:     "numbers out of range or not integral",
+			document: single,
+			variables: map[string]interface{}{
+				"first": int64(math.MaxInt32) + 1,
+				"min":   json.Number("1e400"),
+				"after": json.Number("3.5"),
+			},
+			want: []string{
+				"Variable '$first' expected a value of type Int, got 2147483648",
+				"Variable '$min' expected a value of type Float, got 1e400",
+				"Variable '$after' expected a value of type ID, got 3.5",
+			},
+		},
+		{
+			name:     "input objects and enums",
+			document: single,
+			variables: map[string]interface{}{
+				"filter": map[string]interface{}{"role": "OWNER", "nickname": "x"},
+			},
+			want: []string{
+				"Missing required field '$filter.name' of type String!",
+				`Variable '$filter.role' expected a value of type Role, got "OWNER"`,
+				"Unknown field '$filter.nickname' for input type UserFilter",
+			},
+		},
+		{
+			name:      "missing required and undeclared variables",
+			document:  `query User($id: ID!) { user(id: $id) { id } }`,
+			variables: map[string]interface{}{"userId": "1"},
+			want: []string{
+				"Missing required variable '$id' of type ID!",
+				"Variable '$userId' is not defined by operation 'User'",
+			},
+		},
+		{
+			name: "variables keyed by operation name",
+			document: `
+query User($id: ID!) { user(id: $id) { id } }
+query Users($first: Int) { users(first: $first) { id } }
+`,
+			variables: map[string]interface{}{
+				"User":  map[string]interface{}{"id": "1"},
+				"Users": map[string]interface{}{"first": "ten"},
+			},
+			want: []string{`Variable '$first' expected a value of type Int, got "ten"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents := mustLoadDocuments(t, tt.document)
+			documents[0].Variables = tt.variables
+			results, err := core.ValidateDocuments(schema, documents, nil)
+			if err != nil {
+				t.Fatalf("ValidateDocuments() error = %v", err)
+			}
+
+			var got []string
+			for _, result := range results {
+				for _, diagnostic := range result.Diagnostics {
+					if diagnostic.RuleID == "variables" {
+						got = append(got, diagnostic.Message)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("variables diagnostics = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package loader
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bishnuag/graphql-inspector/pkg/core"
+)
+
+// LoadVariables loads a JSON object of operation variables from a file
+func LoadVariables(path string) (map[string]interface{}, error) {
+	return loadJSONObject(path, "variables")
+}
+
+// LoadResponse loads a recorded GraphQL JSON response from a file
+func LoadResponse(path string) (map[string]interface{}, error) {
+	return loadJSONObject(path, "response")
+}
+
+// LoadTraffic loads operation traffic exported from gateway logs, as JSON lines of
+// {"operationName" or "hash", "count", "timestamp"}. Timestamps are optional and
+// may be RFC 3339 times or plain dates.
+func LoadTraffic(path string) ([]core.OperationTraffic, error) {
+	content, err := loadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var traffic []core.OperationTraffic
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record struct {
+			OperationName string `json:"operationName"`
+			Hash          string `json:"hash"`
+			Count         int64  `json:"count"`
+			Timestamp     string `json:"timestamp"`
+		}
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse usage file %s:%d: %w", path, line, err)
+		}
+		if record.OperationName == "" && record.Hash == "" {
+			return nil, fmt.Errorf("usage file %s:%d: record needs an operationName or hash", path, line)
+		}
+
+		entry := core.OperationTraffic{
+			OperationName: record.OperationName,
+			Hash:          record.Hash,
+			Count:         record.Count,
+		}
+		if record.Timestamp != "" {
+			if entry.Timestamp, err = parseTimestamp(record.Timestamp); err != nil {
+				return nil, fmt.Errorf("usage file %s:%d: %w", path, line, err)
+			}
+		}
+		traffic = append(traffic, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage file %s: %w", path, err)
+	}
+
+	return traffic, nil
+}
+
+// LoadCoverageBaseline loads a coverage result saved by an earlier run, either as
+// written by --save-baseline or as the full --json coverage output
+func LoadCoverageBaseline(path string) (*core.CoverageResult, error) {
+	content, err := loadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The --json output nests the result under "coverage", where a saved result
+	// holds its overall ratio
+	var wrapper struct {
+		Coverage json.RawMessage `json:"coverage"`
+	}
+	if err := json.Unmarshal([]byte(content), &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse coverage baseline %s: %w", path, err)
+	}
+	data := []byte(content)
+	if trimmed := strings.TrimSpace(string(wrapper.Coverage)); strings.HasPrefix(trimmed, "{") {
+		data = wrapper.Coverage
+	}
+
+	var result core.CoverageResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse coverage baseline %s: %w", path, err)
+	}
+	if result.Details == nil {
+		return nil, fmt.Errorf("coverage baseline %s has no coverage details", path)
+	}
+
+	return &result, nil
+}
+
+// parseTimestamp parses an RFC 3339 time or a plain date
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q, expected RFC 3339 or YYYY-MM-DD", value)
+	}
+	return t, nil
+}
+
+// loadJSONObject loads a file that must contain a JSON object
+func loadJSONObject(path, what string) (map[string]interface{}, error) {
+	content, err := loadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &object); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s: %w", what, path, err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("%s %s must contain a JSON object", what, path)
+	}
+
+	return object, nil
+}
+
+// variablesSidecarPath returns the path of the variables file that accompanies a
+// document, e.g. queries/getUser.variables.json for queries/getUser.graphql
+func variablesSidecarPath(documentPath string) string {
+	return strings.TrimSuffix(documentPath, filepath.Ext(documentPath)) + ".variables.json"
+}
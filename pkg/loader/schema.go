@@ -12,8 +12,6 @@ import (
 	"time"
 
 	"github.com/bishnuag/graphql-inspector/pkg/core"
-	"github.com/graphql-go/graphql"
-	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/parser"
 )
 
@@ -104,11 +102,22 @@ func LoadDocument(source string) (*core.Document, error) {
 	// Create hash for the document
 	hash := createHash(content)
 
+	// Load variables from a sidecar file next to the document, if there is one
+	var variables map[string]interface{}
+	if !isURL(source) && isGraphQLFile(source) {
+		if sidecar := variablesSidecarPath(source); isFile(sidecar) {
+			if variables, err = LoadVariables(sidecar); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return &core.Document{
-		Source:  source,
-		Content: content,
-		AST:     docAST,
-		Hash:    hash,
+		Source:    source,
+		Content:   content,
+		AST:       docAST,
+		Hash:      hash,
+		Variables: variables,
 	}, nil
 }
 
@@ -170,103 +179,6 @@ func LoadDocuments(pattern string) ([]core.Document, error) {
 	return documents, nil
 }
 
-// buildSchemaFromSDL builds a GraphQL schema from SDL
-func buildSchemaFromSDL(sdl string) (*graphql.Schema, error) {
-	// Parse the SDL
-	doc, err := parser.Parse(parser.ParseParams{
-		Source: sdl,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse SDL: %w", err)
-	}
-
-	// TODO: Implement proper SDL to schema conversion
-	// The graphql-go library doesn't have a direct schema_from_ast utility
-	// A proper implementation would:
-	// 1. Walk the AST and extract type definitions
-	// 2. Build GraphQL types from the definitions
-	// 3. Create the schema with proper types and resolvers
-	//
-	// For now, we'll create a basic schema to demonstrate the structure
-	// In a production implementation, you would parse the AST and build the schema
-
-	// Extract basic information from the parsed SDL (simplified)
-	hasQuery := false
-	hasMutation := false
-	hasSubscription := false
-
-	for _, def := range doc.Definitions {
-		switch def := def.(type) {
-		case *ast.SchemaDefinition:
-			for _, opType := range def.OperationTypes {
-				switch opType.Operation {
-				case "query":
-					hasQuery = true
-				case "mutation":
-					hasMutation = true
-				case "subscription":
-					hasSubscription = true
-				}
-			}
-		}
-	}
-
-	// Create a basic schema config
-	schemaConfig := graphql.SchemaConfig{}
-
-	// Create query type (required)
-	if hasQuery || len(doc.Definitions) > 0 {
-		schemaConfig.Query = graphql.NewObject(graphql.ObjectConfig{
-			Name: "Query",
-			Fields: graphql.Fields{
-				"hello": &graphql.Field{
-					Type: graphql.String,
-					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return "Hello from GraphQL Inspector!", nil
-					},
-				},
-			},
-		})
-	}
-
-	// Create mutation type if detected
-	if hasMutation {
-		schemaConfig.Mutation = graphql.NewObject(graphql.ObjectConfig{
-			Name: "Mutation",
-			Fields: graphql.Fields{
-				"noop": &graphql.Field{
-					Type: graphql.String,
-					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return "noop", nil
-					},
-				},
-			},
-		})
-	}
-
-	// Create subscription type if detected
-	if hasSubscription {
-		schemaConfig.Subscription = graphql.NewObject(graphql.ObjectConfig{
-			Name: "Subscription",
-			Fields: graphql.Fields{
-				"noop": &graphql.Field{
-					Type: graphql.String,
-					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return "noop", nil
-					},
-				},
-			},
-		})
-	}
-
-	schema, err := graphql.NewSchema(schemaConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build schema: %w", err)
-	}
-
-	return &schema, nil
-}
-
 // isURL checks if a string is a URL
 func isURL(s string) bool {
 	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
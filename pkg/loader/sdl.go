@@ -0,0 +1,491 @@
+package loader
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// sdlBuilder builds executable-free GraphQL types from SDL type definitions
+type sdlBuilder struct {
+	definitions map[string]ast.Node
+	extensions  map[string][]*ast.ObjectDefinition
+	types       map[string]graphql.Type
+	order       []string
+	err         error
+}
+
+// buildSchemaFromSDL builds a GraphQL schema from SDL
+func buildSchemaFromSDL(sdl string) (*graphql.Schema, error) {
+	// Parse the SDL
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: sdl,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SDL: %w", err)
+	}
+
+	builder := &sdlBuilder{
+		definitions: make(map[string]ast.Node),
+		extensions:  make(map[string][]*ast.ObjectDefinition),
+		types:       make(map[string]graphql.Type),
+	}
+
+	rootTypes := map[string]string{
+		"query":        "Query",
+		"mutation":     "Mutation",
+		"subscription": "Subscription",
+	}
+	var directiveDefs []*ast.DirectiveDefinition
+
+	// Collect type definitions by name
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			for _, opType := range def.OperationTypes {
+				rootTypes[opType.Operation] = opType.Type.Name.Value
+			}
+		case *ast.ObjectDefinition:
+			builder.addDefinition(def.Name.Value, def)
+		case *ast.InterfaceDefinition:
+			builder.addDefinition(def.Name.Value, def)
+		case *ast.UnionDefinition:
+			builder.addDefinition(def.Name.Value, def)
+		case *ast.EnumDefinition:
+			builder.addDefinition(def.Name.Value, def)
+		case *ast.InputObjectDefinition:
+			builder.addDefinition(def.Name.Value, def)
+		case *ast.ScalarDefinition:
+			builder.addDefinition(def.Name.Value, def)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				name := def.Definition.Name.Value
+				builder.extensions[name] = append(builder.extensions[name], def.Definition)
+			}
+		case *ast.DirectiveDefinition:
+			directiveDefs = append(directiveDefs, def)
+		}
+	}
+
+	// Build every named type so unreachable types are part of the schema too
+	var types []graphql.Type
+	for _, name := range builder.order {
+		t, err := builder.namedType(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+
+	schemaConfig := graphql.SchemaConfig{Types: types}
+
+	query, err := builder.rootType(rootTypes["query"])
+	if err != nil {
+		return nil, err
+	}
+	if query == nil {
+		return nil, fmt.Errorf("schema does not define a query root type")
+	}
+	schemaConfig.Query = query
+
+	if schemaConfig.Mutation, err = builder.rootType(rootTypes["mutation"]); err != nil {
+		return nil, err
+	}
+	if schemaConfig.Subscription, err = builder.rootType(rootTypes["subscription"]); err != nil {
+		return nil, err
+	}
+
+	// Keep the specified directives and add the ones declared in the SDL
+	if len(directiveDefs) > 0 {
+		schemaConfig.Directives = append(schemaConfig.Directives, graphql.SpecifiedDirectives...)
+		for _, def := range directiveDefs {
+			directive, err := builder.directive(def)
+			if err != nil {
+				return nil, err
+			}
+			schemaConfig.Directives = append(schemaConfig.Directives, directive)
+		}
+	}
+
+	schema, err := graphql.NewSchema(schemaConfig)
+
+	// Field types are resolved lazily, so surface any unknown references now;
+	// they also leave the schema invalid, with a less helpful error
+	if builder.err != nil {
+		return nil, builder.err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build schema: %w", err)
+	}
+
+	return &schema, nil
+}
+
+// addDefinition registers a named type definition, keeping declaration order
+func (b *sdlBuilder) addDefinition(name string, def ast.Node) {
+	if _, exists := b.definitions[name]; !exists {
+		b.order = append(b.order, name)
+	}
+	b.definitions[name] = def
+}
+
+// fail records the first error raised while resolving lazily built fields
+func (b *sdlBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// rootType resolves an operation root type, returning nil when it is not defined
+func (b *sdlBuilder) rootType(name string) (*graphql.Object, error) {
+	if _, exists := b.definitions[name]; !exists {
+		return nil, nil
+	}
+
+	t, err := b.namedType(name)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := t.(*graphql.Object)
+	if !ok {
+		return nil, fmt.Errorf("root type '%s' must be an object type", name)
+	}
+	return object, nil
+}
+
+// namedType returns the GraphQL type for a name, building it on first use
+func (b *sdlBuilder) namedType(name string) (graphql.Type, error) {
+	if t, exists := b.types[name]; exists {
+		return t, nil
+	}
+
+	switch name {
+	case "String":
+		return graphql.String, nil
+	case "Int":
+		return graphql.Int, nil
+	case "Float":
+		return graphql.Float, nil
+	case "Boolean":
+		return graphql.Boolean, nil
+	case "ID":
+		return graphql.ID, nil
+	}
+
+	def, exists := b.definitions[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown type '%s'", name)
+	}
+
+	var t graphql.Type
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		t = b.buildObject(def)
+	case *ast.InterfaceDefinition:
+		t = b.buildInterface(def)
+	case *ast.UnionDefinition:
+		union, err := b.buildUnion(def)
+		if err != nil {
+			return nil, err
+		}
+		t = union
+	case *ast.EnumDefinition:
+		t = buildEnum(def)
+	case *ast.InputObjectDefinition:
+		t = b.buildInputObject(def)
+	case *ast.ScalarDefinition:
+		t = buildScalar(def)
+	default:
+		return nil, fmt.Errorf("unsupported definition for type '%s'", name)
+	}
+
+	b.types[name] = t
+	return t, nil
+}
+
+// typeRef resolves an AST type reference such as [User!]! to a GraphQL type
+func (b *sdlBuilder) typeRef(ref ast.Type) (graphql.Type, error) {
+	switch ref := ref.(type) {
+	case *ast.NonNull:
+		ofType, err := b.typeRef(ref.Type)
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewNonNull(ofType), nil
+	case *ast.List:
+		ofType, err := b.typeRef(ref.Type)
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewList(ofType), nil
+	case *ast.Named:
+		return b.namedType(ref.Name.Value)
+	default:
+		return nil, fmt.Errorf("unsupported type reference %T", ref)
+	}
+}
+
+// buildObject builds an object type; fields and interfaces are thunks so types may reference each other
+func (b *sdlBuilder) buildObject(def *ast.ObjectDefinition) *graphql.Object {
+	name := def.Name.Value
+	fieldDefs := def.Fields
+	interfaceRefs := def.Interfaces
+	for _, ext := range b.extensions[name] {
+		fieldDefs = append(fieldDefs, ext.Fields...)
+		interfaceRefs = append(interfaceRefs, ext.Interfaces...)
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        name,
+		Description: descriptionOf(def.Description),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return b.buildFields(fieldDefs)
+		}),
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+			var interfaces []*graphql.Interface
+			for _, ref := range interfaceRefs {
+				t, err := b.namedType(ref.Name.Value)
+				if err != nil {
+					b.fail(err)
+					continue
+				}
+				if iface, ok := t.(*graphql.Interface); ok {
+					interfaces = append(interfaces, iface)
+				}
+			}
+			return interfaces
+		}),
+	})
+}
+
+// buildInterface builds an interface type
+func (b *sdlBuilder) buildInterface(def *ast.InterfaceDefinition) *graphql.Interface {
+	name := def.Name.Value
+	fieldDefs := def.Fields
+
+	return graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        name,
+		Description: descriptionOf(def.Description),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return b.buildFields(fieldDefs)
+		}),
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+	})
+}
+
+// buildUnion builds a union type from its member object types
+func (b *sdlBuilder) buildUnion(def *ast.UnionDefinition) (*graphql.Union, error) {
+	var members []*graphql.Object
+	for _, ref := range def.Types {
+		t, err := b.namedType(ref.Name.Value)
+		if err != nil {
+			return nil, err
+		}
+		object, ok := t.(*graphql.Object)
+		if !ok {
+			return nil, fmt.Errorf("union '%s' member '%s' must be an object type", def.Name.Value, ref.Name.Value)
+		}
+		members = append(members, object)
+	}
+
+	return graphql.NewUnion(graphql.UnionConfig{
+		Name:        def.Name.Value,
+		Description: descriptionOf(def.Description),
+		Types:       members,
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+	}), nil
+}
+
+// buildInputObject builds an input object type
+func (b *sdlBuilder) buildInputObject(def *ast.InputObjectDefinition) *graphql.InputObject {
+	fieldDefs := def.Fields
+
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        def.Name.Value,
+		Description: descriptionOf(def.Description),
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			fields := graphql.InputObjectConfigFieldMap{}
+			for _, fieldDef := range fieldDefs {
+				t, err := b.typeRef(fieldDef.Type)
+				if err != nil {
+					b.fail(err)
+					continue
+				}
+				fields[fieldDef.Name.Value] = &graphql.InputObjectFieldConfig{
+					Type:         t,
+					DefaultValue: valueOf(fieldDef.DefaultValue),
+					Description:  descriptionOf(fieldDef.Description),
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+// buildFields builds the output fields of an object or interface type
+func (b *sdlBuilder) buildFields(fieldDefs []*ast.FieldDefinition) graphql.Fields {
+	fields := graphql.Fields{}
+	for _, fieldDef := range fieldDefs {
+		t, err := b.typeRef(fieldDef.Type)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+
+		args := graphql.FieldConfigArgument{}
+		for _, argDef := range fieldDef.Arguments {
+			argType, err := b.typeRef(argDef.Type)
+			if err != nil {
+				b.fail(err)
+				continue
+			}
+			args[argDef.Name.Value] = &graphql.ArgumentConfig{
+				Type:         argType,
+				DefaultValue: valueOf(argDef.DefaultValue),
+				Description:  descriptionOf(argDef.Description),
+			}
+		}
+
+		fields[fieldDef.Name.Value] = &graphql.Field{
+			Name:              fieldDef.Name.Value,
+			Type:              t,
+			Args:              args,
+			Description:       descriptionOf(fieldDef.Description),
+			DeprecationReason: deprecationReason(fieldDef.Directives),
+		}
+	}
+	return fields
+}
+
+// directive builds a custom directive declared in the SDL
+func (b *sdlBuilder) directive(def *ast.DirectiveDefinition) (*graphql.Directive, error) {
+	args := graphql.FieldConfigArgument{}
+	for _, argDef := range def.Arguments {
+		argType, err := b.typeRef(argDef.Type)
+		if err != nil {
+			return nil, err
+		}
+		args[argDef.Name.Value] = &graphql.ArgumentConfig{
+			Type:         argType,
+			DefaultValue: valueOf(argDef.DefaultValue),
+			Description:  descriptionOf(argDef.Description),
+		}
+	}
+
+	var locations []string
+	for _, location := range def.Locations {
+		locations = append(locations, location.Value)
+	}
+
+	return graphql.NewDirective(graphql.DirectiveConfig{
+		Name:        def.Name.Value,
+		Description: descriptionOf(def.Description),
+		Locations:   locations,
+		Args:        args,
+	}), nil
+}
+
+// buildEnum builds an enum type
+func buildEnum(def *ast.EnumDefinition) *graphql.Enum {
+	values := graphql.EnumValueConfigMap{}
+	for _, valueDef := range def.Values {
+		values[valueDef.Name.Value] = &graphql.EnumValueConfig{
+			Value:             valueDef.Name.Value,
+			Description:       descriptionOf(valueDef.Description),
+			DeprecationReason: deprecationReason(valueDef.Directives),
+		}
+	}
+
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name:        def.Name.Value,
+		Description: descriptionOf(def.Description),
+		Values:      values,
+	})
+}
+
+// buildScalar builds a custom scalar that accepts any value
+func buildScalar(def *ast.ScalarDefinition) *graphql.Scalar {
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        def.Name.Value,
+		Description: descriptionOf(def.Description),
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+		ParseValue: func(value interface{}) interface{} {
+			return value
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return valueOf(valueAST)
+		},
+	})
+}
+
+// descriptionOf returns the value of an optional description
+func descriptionOf(desc *ast.StringValue) string {
+	if desc == nil {
+		return ""
+	}
+	return desc.Value
+}
+
+// deprecationReason returns the @deprecated reason, or an empty string if not deprecated
+func deprecationReason(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name.Value != "deprecated" {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name.Value == "reason" {
+				if reason, ok := arg.Value.(*ast.StringValue); ok {
+					return reason.Value
+				}
+			}
+		}
+		return graphql.DefaultDeprecationReason
+	}
+	return ""
+}
+
+// valueOf converts a constant AST value to its Go representation
+func valueOf(value ast.Value) interface{} {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		if i, err := strconv.Atoi(value.Value); err == nil {
+			return i
+		}
+		return value.Value
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(value.Value, 64); err == nil {
+			return f
+		}
+		return value.Value
+	case *ast.StringValue:
+		return value.Value
+	case *ast.BooleanValue:
+		return value.Value
+	case *ast.EnumValue:
+		return value.Value
+	case *ast.ListValue:
+		list := make([]interface{}, 0, len(value.Values))
+		for _, item := range value.Values {
+			list = append(list, valueOf(item))
+		}
+		return list
+	case *ast.ObjectValue:
+		object := make(map[string]interface{}, len(value.Fields))
+		for _, field := range value.Fields {
+			object[field.Name.Value] = valueOf(field.Value)
+		}
+		return object
+	default:
+		return nil
+	}
+}
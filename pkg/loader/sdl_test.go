@@ -0,0 +1,147 @@
+package loader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestBuildSchemaFromSDL(t *testing.T) {
+	tests := []struct {
+		name  string
+		sdl   string
+		check func(t *testing.T, schema *graphql.Schema)
+	}{
+		{
+			name: "type extensions add fields and interfaces",
+			sdl: `
+interface Node { id: ID! }
+type Query { user: User }
+type User { name: String }
+extend type User implements Node { id: ID! }
+extend type Query { node(id: ID!): Node }
+`,
+			check: func(t *testing.T, schema *graphql.Schema) {
+				user := schema.Type("User").(*graphql.Object)
+				if _, ok := user.Fields()["id"]; !ok {
+					t.Errorf("User is missing the extended field id")
+				}
+				if interfaces := user.Interfaces(); len(interfaces) != 1 || interfaces[0].Name() != "Node" {
+					t.Errorf("User interfaces = %v, want [Node]", interfaces)
+				}
+				if _, ok := schema.QueryType().Fields()["node"]; !ok {
+					t.Errorf("Query is missing the extended field node")
+				}
+			},
+		},
+		{
+			name: "directive definitions are added to the specified directives",
+			sdl: `
+directive @cost(weight: Int = 1) on FIELD_DEFINITION | OBJECT
+type Query { users: [String] @cost(weight: 5) }
+`,
+			check: func(t *testing.T, schema *graphql.Schema) {
+				cost := schema.Directive("cost")
+				if cost == nil {
+					t.Fatalf("directive @cost is missing")
+				}
+				if got := strings.Join(cost.Locations, ","); got != "FIELD_DEFINITION,OBJECT" {
+					t.Errorf("@cost locations = %s, want FIELD_DEFINITION,OBJECT", got)
+				}
+				if len(cost.Args) != 1 || cost.Args[0].Name() != "weight" || cost.Args[0].DefaultValue != 1 {
+					t.Errorf("@cost args = %v, want weight with default 1", cost.Args)
+				}
+				if schema.Directive("deprecated") == nil {
+					t.Errorf("specified directive @deprecated is missing")
+				}
+			},
+		},
+		{
+			name: "deprecations and custom root types",
+			sdl: `
+schema { query: Root }
+type Root { old: String @deprecated(reason: "use new") new: Status }
+enum Status { ACTIVE LEGACY @deprecated }
+`,
+			check: func(t *testing.T, schema *graphql.Schema) {
+				if name := schema.QueryType().Name(); name != "Root" {
+					t.Errorf("query root = %s, want Root", name)
+				}
+				if reason := schema.QueryType().Fields()["old"].DeprecationReason; reason != "use new" {
+					t.Errorf("Root.old deprecation reason = %q, want %q", reason, "use new")
+				}
+				for _, value := range schema.Type("Status").(*graphql.Enum).Values() {
+					if value.Name == "LEGACY" && value.DeprecationReason != graphql.DefaultDeprecationReason {
+						t.Errorf("Status.LEGACY deprecation reason = %q", value.DeprecationReason)
+					}
+				}
+			},
+		},
+		{
+			name: "unreachable types are part of the schema",
+			sdl: `
+type Query { ok: Boolean }
+type Orphan { id: ID }
+`,
+			check: func(t *testing.T, schema *graphql.Schema) {
+				if schema.Type("Orphan") == nil {
+					t.Errorf("unreachable type Orphan is missing")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := buildSchemaFromSDL(tt.sdl)
+			if err != nil {
+				t.Fatalf("buildSchemaFromSDL() error = %v", err)
+			}
+			tt.check(t, schema)
+		})
+	}
+}
+
+func TestBuildSchemaFromSDLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sdl  string
+		want string
+	}{
+		{
+			name: "missing query root",
+			sdl:  `type User { id: ID }`,
+			want: "schema does not define a query root type",
+		},
+		{
+			name: "root type that is not an object",
+			sdl:  `schema { query: Status } enum Status { ACTIVE }`,
+			want: "root type 'Status' must be an object type",
+		},
+		{
+			name: "unknown field type",
+			sdl:  `type Query { user: User }`,
+			want: "unknown type 'User'",
+		},
+		{
+			name: "union member that is not an object",
+			sdl:  `type Query { result: Result } union Result = Status enum Status { ACTIVE }`,
+			want: "union 'Result' member 'Status' must be an object type",
+		},
+		{
+			name: "invalid SDL",
+			sdl:  `type Query {`,
+			want: "failed to parse SDL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildSchemaFromSDL(tt.sdl)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("buildSchemaFromSDL() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}